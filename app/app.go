@@ -2,17 +2,23 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/achyar10/snmp-olt-zte/config"
 	"github.com/achyar10/snmp-olt-zte/internal/handler"
+	"github.com/achyar10/snmp-olt-zte/internal/model"
 	"github.com/achyar10/snmp-olt-zte/internal/repository"
 	"github.com/achyar10/snmp-olt-zte/internal/usecase"
 	"github.com/achyar10/snmp-olt-zte/internal/utils"
+	"github.com/achyar10/snmp-olt-zte/pkg/cache"
 	"github.com/achyar10/snmp-olt-zte/pkg/graceful"
 	"github.com/achyar10/snmp-olt-zte/pkg/redis"
+	"github.com/achyar10/snmp-olt-zte/pkg/server"
 	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmpsim"
 	rds "github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
 )
@@ -42,7 +48,7 @@ func (a *App) Start(ctx context.Context) error {
 	// Check Redis connection
 	err = redisClient.Ping(ctx).Err()
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to ping Redis server")
+		log.Error().Err(err).Msg("Failed to ping Redis server, the in-memory cache is used until it is reachable")
 	} else {
 		log.Info().Msg("Redis server successfully connected")
 	}
@@ -55,59 +61,139 @@ func (a *App) Start(ctx context.Context) error {
 		}
 	}(redisClient)
 
-	// Initialize SNMP connection
-	snmpConn, err := snmp.SetupSnmpConnection(cfg)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to setup SNMP connection")
+	// Initialize OLT registry, with a single default OLT if no OLT list is configured
+	oltDevices := cfg.OltsCfg
+	if len(oltDevices) == 0 {
+		oltDevices = []config.OltDeviceConfig{{
+			ID:     repository.DefaultOltID,
+			Name:   repository.DefaultOltID,
+			Snmp:   snmp.LoadSnmpConfig(cfg),
+			Telnet: cfg.TelnetCfg,
+		}}
 	}
 
-	// Check SNMP connection
-	/*
-		if SNMP Connection with wrong credentials in SNMP v3, return error is nil
-		if SNMP Connection with wrong Port in SNMP v2 v2c, return error is nil
-		if SNMP Connection with wrong community v2 v2c, return error is nil
+	oltRepo := repository.NewOltRepository()
+	for _, device := range oltDevices {
+		// Initialize SNMP connection
+		snmpConn, err := snmp.SetupSnmpConnection(device.Snmp)
+		if err != nil {
+			log.Error().Err(err).Str("olt_id", device.ID).Msg("Failed to setup SNMP connection")
+		} else {
+			// Check SNMP connection
+			/*
+				if SNMP Connection with wrong credentials in SNMP v3, return error is nil
+				if SNMP Connection with wrong Port in SNMP v2 v2c, return error is nil
+				if SNMP Connection with wrong community v2 v2c, return error is nil
+
+				Connect creates and opens a socket. Because UDP is a connectionless protocol,
+				you won't know if the remote host is responding until you send packets.
+				Neither will you know if the host is regularly disappearing and reappearing.
+			*/
+			log.Info().Str("olt_id", device.ID).Msg("SNMP server successfully connected")
+
+			// Close SNMP connection, it is only used to check the configuration
+			if err := snmpConn.Conn.Close(); err != nil {
+				log.Error().Err(err).Str("olt_id", device.ID).Msg("Failed to close SNMP connection")
+			}
+		}
 
-		Connect creates and opens a socket. Because UDP is a connectionless protocol,
-		you won't know if the remote host is responding until you send packets.
-		Neither will you know if the host is regularly disappearing and reappearing.
-	*/
+		// Load the time zone of the OLT clock, UTC when none is configured
+		timezone := device.Timezone
+		if timezone == "" {
+			timezone = cfg.OltCfg.Timezone
+		}
+		clock, err := time.LoadLocation(timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone of OLT %s: %w", device.ID, err)
+		}
 
-	if snmpConn.Connect() != nil {
-		log.Error().Err(err).Msg("Failed to connect to SNMP server")
-	} else {
-		log.Info().Msg("SNMP server successfully connected")
-	}
+		// Use the optical thresholds of the OLT, or the thresholds shared by all OLTs
+		optical := cfg.OltCfg.OpticalThresholds
+		if device.OpticalThresholds != nil {
+			optical = *device.OpticalThresholds
+		}
 
-	// Close SNMP connection after application shutdown
-	defer func() {
-		if err := snmpConn.Conn.Close(); err != nil {
-			log.Error().Err(err).Msg("Failed to close SNMP connection")
+		// Initialize SNMP session pool, closed after application shutdown
+		snmpPool := snmp.NewPool(device.Snmp)
+		defer snmpPool.Close()
+
+		snmpRepo := repository.NewPonRepository(snmpPool)
+
+		// Serve a recorded walk instead of the agent
+		if device.Snmp.ReplayFile != "" {
+			store, err := snmpsim.LoadFile(device.Snmp.ReplayFile)
+			if err != nil {
+				return err
+			}
+			snmpRepo = repository.NewReplayRepository(store)
+			log.Info().Str("olt_id", device.ID).Str("replay_file", device.Snmp.ReplayFile).Msg("SNMP replay enabled")
 		}
-	}()
 
-	// Initialize repository
-	snmpRepo := repository.NewPonRepository(snmpConn.Target, snmpConn.Community, snmpConn.Port)
-	redisRepo := repository.NewOnuRedisRepo(redisClient)
+		// Record every varbind read into a snmprec fixture, closed after application shutdown
+		if device.Snmp.RecordFile != "" {
+			recordFile, err := os.OpenFile(device.Snmp.RecordFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+			if err != nil {
+				return err
+			}
+			defer recordFile.Close()
+
+			snmpRepo = repository.NewRecordingRepository(snmpRepo, snmpsim.NewRecorder(recordFile))
+			log.Info().Str("olt_id", device.ID).Str("record_file", device.Snmp.RecordFile).Msg("SNMP recording enabled")
+		}
+
+		// Initialize circuit breaker, probing the agent with a sysUpTime Get when half-open
+		breaker := snmp.NewBreaker(device.Snmp, func(ctx context.Context) error {
+			_, err := snmpRepo.Get(ctx, []string{snmp.SysUpTimeOID})
+			return err
+		})
+
+		// Register OLT with its repositories
+		oltRepo.Register(model.Olt{
+			ID:       device.ID,
+			Name:     device.Name,
+			Model:    device.Model,
+			Host:     device.Snmp.Ip,
+			Timezone: clock.String(),
+		}, repository.OltConnection{
+			Snmp:    repository.NewBreakerRepository(snmpRepo, breaker),
+			Breaker: breaker,
+			Limiter: snmpPool.Limiter(),
+			Clock:   clock,
+			Optical: optical,
+			OID:     device.OID,
+			Telnet: repository.NewTelnetRepository(
+				device.Telnet.Ip, device.Telnet.Port, device.Telnet.Username, device.Telnet.Password,
+			),
+		})
+	}
+
+	// Initialize cache, kept in memory while Redis is unreachable
+	cacheBackend := cache.NewFallback(cache.NewRedis(redisClient), cache.NewMemory(cfg.CacheCfg), cfg.CacheCfg)
 
 	// Initialize usecase
-	onuUsecase := usecase.NewOnuUsecase(snmpRepo, redisRepo, cfg)
+	onuUsecase := usecase.NewOnuUsecase(oltRepo, cacheBackend, cfg)
+	oltUsecase := usecase.NewOltUsecase(oltRepo, cfg)
+
+	// Discover the GPON boards and PONs of every OLT at startup and periodically
+	go oltUsecase.WatchTopology(ctx)
 
 	// Initialize handler
 	onuHandler := handler.NewOnuHandler(onuUsecase)
+	oltHandler := handler.NewOltHandler(oltUsecase)
 
 	// Initialize router
-	a.router = loadRoutes(onuHandler)
+	a.router = loadRoutes(onuHandler, oltHandler)
 
-	// Start server
-	addr := "8081"
-	server := &http.Server{
-		Addr:    ":" + addr,
-		Handler: a.router,
+	// Initialize server
+	serverCfg := server.LoadServerConfig(cfg)
+	httpServer, err := server.NewServer(serverCfg, a.router)
+	if err != nil {
+		return err
 	}
 
 	// Start server at given address
-	log.Info().Msgf("Application started at %s", addr)
+	log.Info().Str("addr", httpServer.Addr).Bool("tls", httpServer.TLSConfig != nil).Msg("Application started")
 
 	// Graceful shutdown
-	return graceful.Shutdown(ctx, server)
+	return graceful.Shutdown(ctx, httpServer)
 }
@@ -11,7 +11,7 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func loadRoutes(onuHandler *handler.OnuHandler) http.Handler {
+func loadRoutes(onuHandler *handler.OnuHandler, oltHandler *handler.OltHandler) http.Handler {
 
 	// Initialize logger
 	l := log.Output(zerolog.ConsoleWriter{
@@ -33,32 +33,93 @@ func loadRoutes(onuHandler *handler.OnuHandler) http.Handler {
 	// Create a group for /api/v1/
 	apiV1Group := chi.NewRouter()
 
-	// Define routes for /api/v1/
-	apiV1Group.Route("/board", func(r chi.Router) {
-		r.Get("/{board_id}/pon/{pon_id}", onuHandler.GetByBoardIDAndPonID)
-		r.Get("/{board_id}/pon/{pon_id}/onu/{onu_id}", onuHandler.GetByBoardIDPonIDAndOnuID)
-		r.Get("/{board_id}/pon/{pon_id}/onu_id/empty", onuHandler.GetEmptyOnuID)
-		r.Get("/{board_id}/pon/{pon_id}/onu_id_sn", onuHandler.GetOnuIDAndSerialNumber)
-		r.Get("/{board_id}/pon/{pon_id}/onu_id/update", onuHandler.UpdateEmptyOnuID)
-	})
+	// Define routes of the default OLT for /api/v1/
+	apiV1Group.Group(oltRoutes(onuHandler, oltHandler))
 
-	// Define routes for /api/v1/onu
-	apiV1Group.Route("/onu", func(r chi.Router) {
-		r.Get("/unactivated", onuHandler.GetUnactivatedONU)
-		r.Post("/register", onuHandler.ActivateONU)
-	})
+	// Define routes for /api/v1/olts
+	apiV1Group.Get("/olts", oltHandler.GetOltList)
 
-	// Define routes for /api/v1/paginate
-	apiV1Group.Route("/paginate", func(r chi.Router) {
-		r.Get("/board/{board_id}/pon/{pon_id}", onuHandler.GetByBoardIDAndPonIDWithPaginate)
+	// Define routes for /api/v1/olt, the chassis of the default OLT
+	apiV1Group.Get("/olt", oltHandler.GetOltChassis)
+
+	// Define routes of a specific OLT for /api/v1/olt/{olt_id}
+	apiV1Group.Route("/olt/{olt_id}", func(r chi.Router) {
+		r.Get("/", oltHandler.GetOltChassis)
+		oltRoutes(onuHandler, oltHandler)(r)
 	})
 
 	// Mount /api/v1/ to root router
 	router.Mount("/api/v1", apiV1Group)
 
+	// Create a group for /api/v2/, serving typed ONU information
+	apiV2Group := chi.NewRouter()
+
+	// Define routes of the default OLT and of a specific OLT for /api/v2/
+	apiV2Group.Group(oltRoutesV2(onuHandler))
+	apiV2Group.Route("/olt/{olt_id}", oltRoutesV2(onuHandler))
+
+	// Mount /api/v2/ to root router
+	router.Mount("/api/v2", apiV2Group)
+
 	return router
 }
 
+// oltRoutes defines the routes served for every OLT
+func oltRoutes(onuHandler *handler.OnuHandler, oltHandler *handler.OltHandler) func(r chi.Router) {
+	return func(r chi.Router) {
+		// Define routes for /board
+		r.Route("/board", func(r chi.Router) {
+			r.Get("/{board_id}/pon/{pon_id}", onuHandler.GetByBoardIDAndPonID)
+			r.Get("/{board_id}/pon/{pon_id}/onu/{onu_id}", onuHandler.GetByBoardIDPonIDAndOnuID)
+			r.Get("/{board_id}/pon/{pon_id}/onu/{onu_id}/uni", onuHandler.GetONUUniPorts)
+			r.Get("/{board_id}/pon/{pon_id}/port", onuHandler.GetPonPort)
+			r.Get("/{board_id}/pon/{pon_id}/traffic", onuHandler.GetPonTraffic)
+			r.Get("/{board_id}/pon/{pon_id}/inventory", onuHandler.GetONUInventory)
+			r.Get("/{board_id}/pon/{pon_id}/onu_id/empty", onuHandler.GetEmptyOnuID)
+			r.Get("/{board_id}/pon/{pon_id}/onu_id_sn", onuHandler.GetOnuIDAndSerialNumber)
+			r.Get("/{board_id}/pon/{pon_id}/onu_id/update", onuHandler.UpdateEmptyOnuID)
+			r.Get("/{board_id}/optical", onuHandler.GetByBoardIDBelowOpticalThreshold)
+			r.Get("/{board_id}/pon/{pon_id}/optical", onuHandler.GetByBoardIDBelowOpticalThreshold)
+		})
+
+		// Define routes for /topology
+		r.Get("/topology", oltHandler.GetTopology)
+
+		// Define routes for /traffic
+		r.Get("/traffic", onuHandler.GetTraffic)
+
+		// Define routes for /onu
+		r.Route("/onu", func(r chi.Router) {
+			r.Get("/unactivated", onuHandler.GetUnactivatedONU)
+			r.Post("/register", onuHandler.ActivateONU)
+		})
+
+		// Define routes for /paginate
+		r.Route("/paginate", func(r chi.Router) {
+			r.Get("/board/{board_id}/pon/{pon_id}", onuHandler.GetByBoardIDAndPonIDWithPaginate)
+		})
+
+		// Define routes for /snmp
+		r.Route("/snmp", func(r chi.Router) {
+			r.Get("/breaker", oltHandler.GetSnmpBreaker)
+			r.Get("/limiter", oltHandler.GetSnmpLimiter)
+		})
+	}
+}
+
+// oltRoutesV2 defines the /api/v2 routes served for every OLT
+func oltRoutesV2(onuHandler *handler.OnuHandler) func(r chi.Router) {
+	return func(r chi.Router) {
+		// Define routes for /board
+		r.Route("/board", func(r chi.Router) {
+			r.Get("/{board_id}/pon/{pon_id}", onuHandler.GetByBoardIDAndPonIDV2)
+			r.Get("/{board_id}/pon/{pon_id}/onu/{onu_id}", onuHandler.GetByBoardIDPonIDAndOnuIDV2)
+			r.Get("/{board_id}/optical", onuHandler.GetByBoardIDBelowOpticalThresholdV2)
+			r.Get("/{board_id}/pon/{pon_id}/optical", onuHandler.GetByBoardIDBelowOpticalThresholdV2)
+		})
+	}
+}
+
 // rootHandler is a simple handler for root endpoint
 func rootHandler(w http.ResponseWriter, _ *http.Request) {
 	w.WriteHeader(http.StatusOK)                                // Set HTTP status code to 200
@@ -0,0 +1,201 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/handler"
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/internal/usecase"
+	"github.com/achyar10/snmp-olt-zte/pkg/cache"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmpsim"
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+)
+
+// snmpStub is an SnmpRepositoryInterface failing every request with the error
+type snmpStub struct {
+	err error
+}
+
+func (s snmpStub) Get(context.Context, []string) (*gosnmp.SnmpPacket, error)    { return nil, s.err }
+func (s snmpStub) GetBatch(context.Context, []string) ([]gosnmp.SnmpPDU, error) { return nil, s.err }
+func (s snmpStub) Walk(context.Context, string, func(gosnmp.SnmpPDU) error) error {
+	return s.err
+}
+func (s snmpStub) BulkWalk(context.Context, string, func(gosnmp.SnmpPDU) error) error {
+	return s.err
+}
+
+// telnetStub is a TelnetRepositoryInterface without output
+type telnetStub struct{}
+
+func (telnetStub) Run(string) (string, error) { return "", nil }
+
+// newTestRouter returns the routes of the default OLT serving the C320 walk fixture with the OIDs of config/cfg.yaml,
+// of OLT olt-down behind an open circuit breaker and of OLT olt-busy with a full request queue
+func newTestRouter(t *testing.T) http.Handler {
+	cfg, err := config.LoadConfig("../config/cfg")
+	assert.NoError(t, err)
+
+	store, err := snmpsim.LoadFile("../pkg/snmpsim/testdata/zte-c320.walk")
+	assert.NoError(t, err)
+
+	oltRepo := repository.NewOltRepository()
+	// The first OLT is served for the routes without OLT ID
+	for _, olt := range []struct {
+		id   string
+		snmp repository.SnmpRepositoryInterface
+	}{
+		{repository.DefaultOltID, repository.NewReplayRepository(store)},
+		{"olt-down", snmpStub{snmp.ErrCircuitOpen}},
+		{"olt-busy", snmpStub{snmp.ErrLimiterQueueFull}},
+	} {
+		oltRepo.Register(model.Olt{ID: olt.id, Name: olt.id}, repository.OltConnection{
+			Snmp:    olt.snmp,
+			Breaker: snmp.NewBreaker(config.SnmpConfig{}, func(context.Context) error { return nil }),
+			Limiter: snmp.NewLimiter(config.SnmpConfig{}, 1),
+			Telnet:  telnetStub{},
+		})
+	}
+
+	onuUsecase := usecase.NewOnuUsecase(oltRepo, cache.NewMemory(cfg.CacheCfg), cfg)
+	oltUsecase := usecase.NewOltUsecase(oltRepo, cfg)
+	return loadRoutes(handler.NewOnuHandler(onuUsecase), handler.NewOltHandler(oltUsecase))
+}
+
+func TestRoutes(t *testing.T) {
+	router := newTestRouter(t)
+
+	testCases := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "root", path: "/", wantStatus: http.StatusOK, wantBody: "root endpoint"},
+
+		// OLTs and chassis
+		{name: "OLT list", path: "/api/v1/olts", wantStatus: http.StatusOK, wantBody: `"olt_id":"olt-busy"`},
+		{name: "chassis", path: "/api/v1/olt", wantStatus: http.StatusOK, wantBody: `"OLT-C320-LAB"`},
+		{name: "chassis of an OLT", path: "/api/v1/olt/default", wantStatus: http.StatusOK, wantBody: `"OLT-C320-LAB"`},
+		{name: "chassis of an unknown OLT", path: "/api/v1/olt/olt-x", wantStatus: http.StatusNotFound, wantBody: "olt not found"},
+		{name: "chassis of an unreachable OLT", path: "/api/v1/olt/olt-down", wantStatus: http.StatusServiceUnavailable, wantBody: "snmp agent unreachable"},
+		{name: "chassis of a busy OLT", path: "/api/v1/olt/olt-busy", wantStatus: http.StatusServiceUnavailable, wantBody: "snmp agent busy"},
+
+		// Topology
+		{name: "topology", path: "/api/v1/topology", wantStatus: http.StatusOK, wantBody: `"boards":[`},
+		{name: "topology of an unknown OLT", path: "/api/v1/olt/olt-x/topology", wantStatus: http.StatusNotFound},
+
+		// Traffic
+		{name: "traffic", path: "/api/v1/traffic", wantStatus: http.StatusOK, wantBody: `"xgei_1/3/1"`},
+		{name: "traffic of an unreachable OLT", path: "/api/v1/olt/olt-down/traffic", wantStatus: http.StatusServiceUnavailable},
+		{name: "PON traffic", path: "/api/v1/board/1/pon/1/traffic", wantStatus: http.StatusOK, wantBody: `"gpon-olt_1/1/1"`},
+
+		// ONUs of a PON
+		{name: "ONUs", path: "/api/v1/board/1/pon/1", wantStatus: http.StatusOK, wantBody: `"ONU-1-1-1"`},
+		{name: "ONUs of an OLT", path: "/api/v1/olt/default/board/2/pon/16", wantStatus: http.StatusOK, wantBody: `"ONU-2-16-3"`},
+		{name: "invalid board", path: "/api/v1/board/x/pon/1", wantStatus: http.StatusBadRequest, wantBody: "must be a positive number"},
+		{name: "nonexistent board", path: "/api/v1/board/3/pon/1", wantStatus: http.StatusBadRequest, wantBody: "GPON board of the OLT: 1, 2"},
+		{name: "nonexistent PON", path: "/api/v1/board/1/pon/17", wantStatus: http.StatusBadRequest, wantBody: "between 1 and 16"},
+		{name: "invalid query parameter", path: "/api/v1/board/1/pon/1?name=x", wantStatus: http.StatusBadRequest},
+		{name: "ONUs of an unknown OLT", path: "/api/v1/olt/olt-x/board/1/pon/1", wantStatus: http.StatusNotFound},
+		{name: "ONUs of an unreachable OLT", path: "/api/v1/olt/olt-down/board/1/pon/1", wantStatus: http.StatusServiceUnavailable},
+		{name: "ONUs of a busy OLT", path: "/api/v1/olt/olt-busy/board/1/pon/1", wantStatus: http.StatusServiceUnavailable},
+		{name: "paginated ONUs", path: "/api/v1/paginate/board/1/pon/1?page=2&limit=2", wantStatus: http.StatusOK, wantBody: `"ONU-1-1-3"`},
+		{name: "paginated ONUs past the end", path: "/api/v1/paginate/board/1/pon/1?page=5&limit=10", wantStatus: http.StatusNotFound},
+		{name: "paginated ONUs of an unreachable OLT", path: "/api/v1/olt/olt-down/paginate/board/1/pon/1", wantStatus: http.StatusServiceUnavailable},
+
+		// ONU detail, UNI ports, PON port and inventory
+		{name: "ONU", path: "/api/v1/board/1/pon/1/onu/1", wantStatus: http.StatusOK, wantBody: `"ZTEGC01010001"`},
+		{name: "unregistered ONU", path: "/api/v1/board/2/pon/16/onu/2", wantStatus: http.StatusNotFound, wantBody: "data not found"},
+		{name: "ONU ID out of range", path: "/api/v1/board/1/pon/1/onu/129", wantStatus: http.StatusBadRequest, wantBody: "between 1 and 128"},
+		{name: "ONU of an unreachable OLT", path: "/api/v1/olt/olt-down/board/1/pon/1/onu/1", wantStatus: http.StatusServiceUnavailable},
+		{name: "UNI ports", path: "/api/v1/board/1/pon/1/onu/1/uni", wantStatus: http.StatusOK, wantBody: `"eth_0/4"`},
+		{name: "UNI ports of an unreachable OLT", path: "/api/v1/olt/olt-down/board/1/pon/1/onu/1/uni", wantStatus: http.StatusServiceUnavailable},
+		{name: "PON port", path: "/api/v1/board/1/pon/1/port", wantStatus: http.StatusOK, wantBody: `"GPON C+ SFP"`},
+		{name: "PON port of an unreachable OLT", path: "/api/v1/olt/olt-down/board/1/pon/1/port", wantStatus: http.StatusServiceUnavailable},
+		{name: "inventory", path: "/api/v1/board/1/pon/1/inventory", wantStatus: http.StatusOK, wantBody: `"F660"`},
+		{name: "inventory of an unreachable OLT", path: "/api/v1/olt/olt-down/board/1/pon/1/inventory", wantStatus: http.StatusServiceUnavailable},
+
+		// ONU IDs
+		{name: "empty ONU IDs", path: "/api/v1/board/1/pon/1/onu_id/empty", wantStatus: http.StatusOK},
+		{name: "ONU IDs and serial numbers", path: "/api/v1/board/1/pon/1/onu_id_sn", wantStatus: http.StatusOK, wantBody: `"ZTEGC01010001"`},
+		{name: "update empty ONU IDs", path: "/api/v1/board/1/pon/1/onu_id/update", wantStatus: http.StatusOK},
+
+		// Optical power
+		{name: "optical power of a board", path: "/api/v1/board/1/optical?below=-19", wantStatus: http.StatusOK},
+		{name: "optical power of a PON", path: "/api/v1/board/1/pon/1/optical", wantStatus: http.StatusOK},
+		{name: "invalid optical threshold", path: "/api/v1/board/1/optical?below=worse", wantStatus: http.StatusBadRequest},
+
+		// ONU registration
+		{name: "unactivated ONUs", path: "/api/v1/onu/unactivated", wantStatus: http.StatusOK, wantBody: `"detected_onu"`},
+		{name: "register with an invalid payload", method: http.MethodPost, path: "/api/v1/onu/register", body: "{", wantStatus: http.StatusBadRequest},
+		{
+			name: "register without required fields", method: http.MethodPost, path: "/api/v1/onu/register",
+			body: `{"olt_index":"gpon-olt_1/1/1"}`, wantStatus: http.StatusBadRequest, wantBody: "missing required fields",
+		},
+		{
+			name: "register on a nonexistent board", method: http.MethodPost, path: "/api/v1/onu/register",
+			body:       `{"olt_index":"gpon-olt_1/3/1","serial_number":"ZTEGC0000001","region":"r","code":"c"}`,
+			wantStatus: http.StatusBadRequest, wantBody: "GPON board of the OLT",
+		},
+		{
+			name: "register a zero ONU ID", method: http.MethodPost, path: "/api/v1/onu/register",
+			body:       `{"olt_index":"gpon-olt_1/1/1","serial_number":"ZTEGC0000001","region":"r","code":"c","onu":0}`,
+			wantStatus: http.StatusBadRequest, wantBody: "invalid 'onu' field",
+		},
+		{
+			name: "register an ONU ID out of range", method: http.MethodPost, path: "/api/v1/onu/register",
+			body:       `{"olt_index":"gpon-olt_1/1/1","serial_number":"ZTEGC0000001","region":"r","code":"c","onu":129}`,
+			wantStatus: http.StatusBadRequest, wantBody: "between 1 and 128",
+		},
+		{
+			name: "register on an unknown OLT", method: http.MethodPost, path: "/api/v1/olt/olt-x/onu/register",
+			body:       `{"olt_index":"gpon-olt_1/1/1","serial_number":"ZTEGC0000001","region":"r","code":"c"}`,
+			wantStatus: http.StatusNotFound,
+		},
+
+		// SNMP breaker and limiter
+		{name: "breaker", path: "/api/v1/snmp/breaker", wantStatus: http.StatusOK, wantBody: `"state":"closed"`},
+		{name: "breaker of an unknown OLT", path: "/api/v1/olt/olt-x/snmp/breaker", wantStatus: http.StatusNotFound},
+		{name: "limiter", path: "/api/v1/olt/olt-busy/snmp/limiter", wantStatus: http.StatusOK, wantBody: `"olt_id":"olt-busy"`},
+		{name: "limiter of an unknown OLT", path: "/api/v1/olt/olt-x/snmp/limiter", wantStatus: http.StatusNotFound},
+
+		// Typed ONU information
+		{name: "v2 ONUs", path: "/api/v2/board/1/pon/1", wantStatus: http.StatusOK, wantBody: `"signal_quality"`},
+		{name: "v2 ONUs of an OLT", path: "/api/v2/olt/default/board/2/pon/16", wantStatus: http.StatusOK, wantBody: `"ONU-2-16-3"`},
+		{name: "v2 ONU", path: "/api/v2/board/1/pon/1/onu/1", wantStatus: http.StatusOK, wantBody: `"last_online":"2024-05-14T08:01:07Z"`},
+		{name: "v2 nonexistent board", path: "/api/v2/board/3/pon/1", wantStatus: http.StatusBadRequest},
+		{name: "v2 ONU ID out of range", path: "/api/v2/board/1/pon/1/onu/129", wantStatus: http.StatusBadRequest},
+		{name: "v2 optical power of a board", path: "/api/v2/board/1/optical", wantStatus: http.StatusOK},
+		{name: "v2 optical power of a PON", path: "/api/v2/olt/default/board/1/pon/1/optical?below=warning", wantStatus: http.StatusOK},
+		{name: "v2 ONUs of an unknown OLT", path: "/api/v2/olt/olt-x/board/1/pon/1", wantStatus: http.StatusNotFound},
+		{name: "v2 ONUs of an unreachable OLT", path: "/api/v2/olt/olt-down/board/1/pon/1", wantStatus: http.StatusServiceUnavailable},
+		{name: "v2 ONU of a busy OLT", path: "/api/v2/olt/olt-busy/board/1/pon/1/onu/1", wantStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			request := httptest.NewRequest(method, tc.path, strings.NewReader(tc.body))
+			recorder := httptest.NewRecorder()
+
+			router.ServeHTTP(recorder, request)
+
+			assert.Equal(t, tc.wantStatus, recorder.Code, recorder.Body.String())
+			assert.Contains(t, recorder.Body.String(), tc.wantBody)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	_ "time/tzdata" // Embed the time zone database for OLT time zones in images without one
 
 	"github.com/achyar10/snmp-olt-zte/app"
 	"github.com/rs/zerolog/log"
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/achyar10/snmp-olt-zte/pkg/snmpsim"
+	"github.com/rs/zerolog/log"
+)
+
+func main() {
+	addr := flag.String("listen", "127.0.0.1:1161", "UDP address the simulated agent listens on")
+	community := flag.String("community", "public", "SNMP community accepted by the agent, any community when empty")
+	walkFile := flag.String("walk", "pkg/snmpsim/testdata/zte-c320.walk", "recorded walk, snmpwalk -On output or snmprec")
+	flag.Parse()
+
+	// Load the recorded walk
+	store, err := snmpsim.LoadFile(*walkFile)
+	if err != nil {
+		log.Fatal().Err(err).Str("walk", *walkFile).Msg("Failed to load recorded walk")
+	}
+
+	// Bind the simulated agent
+	server := snmpsim.NewServer(store, *community)
+	if err := server.Listen(*addr); err != nil {
+		log.Fatal().Err(err).Str("listen", *addr).Msg("Failed to listen")
+	}
+
+	// Stop the agent on interrupt
+	stopSignal := make(chan os.Signal, 1)
+	signal.Notify(stopSignal, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-stopSignal
+		log.Info().Msg("SNMP simulator is stopping")
+		_ = server.Close()
+	}()
+
+	log.Info().Int("oids", store.Len()).Str("listen", server.Addr().String()).Msg("SNMP simulator started")
+	if err := server.Serve(); err != nil {
+		log.Fatal().Err(err).Msg("SNMP simulator failed")
+	}
+}
@@ -7,48 +7,69 @@ import (
 )
 
 type Config struct {
-	SnmpCfg     SnmpConfig
-	TelnetCfg   TelnetConfig
-	RedisCfg    RedisConfig
-	OltCfg      OltConfig
-	Board1Pon1  Board1Pon1
-	Board1Pon2  Board1Pon2
-	Board1Pon3  Board1Pon3
-	Board1Pon4  Board1Pon4
-	Board1Pon5  Board1Pon5
-	Board1Pon6  Board1Pon6
-	Board1Pon7  Board1Pon7
-	Board1Pon8  Board1Pon8
-	Board1Pon9  Board1Pon9
-	Board1Pon10 Board1Pon10
-	Board1Pon11 Board1Pon11
-	Board1Pon12 Board1Pon12
-	Board1Pon13 Board1Pon13
-	Board1Pon14 Board1Pon14
-	Board1Pon15 Board1Pon15
-	Board1Pon16 Board1Pon16
-	Board2Pon1  Board2Pon1
-	Board2Pon2  Board2Pon2
-	Board2Pon3  Board2Pon3
-	Board2Pon4  Board2Pon4
-	Board2Pon5  Board2Pon5
-	Board2Pon6  Board2Pon6
-	Board2Pon7  Board2Pon7
-	Board2Pon8  Board2Pon8
-	Board2Pon9  Board2Pon9
-	Board2Pon10 Board2Pon10
-	Board2Pon11 Board2Pon11
-	Board2Pon12 Board2Pon12
-	Board2Pon13 Board2Pon13
-	Board2Pon14 Board2Pon14
-	Board2Pon15 Board2Pon15
-	Board2Pon16 Board2Pon16
+	ServerCfg ServerConfig
+	SnmpCfg   SnmpConfig
+	TelnetCfg TelnetConfig
+	RedisCfg  RedisConfig
+	CacheCfg  CacheConfig
+	OltCfg    OltConfig
+	OltsCfg   []OltDeviceConfig
+}
+
+// OltDeviceConfig describes one OLT managed by this service.
+// When OltsCfg is empty, a single "default" OLT is built from SnmpCfg and TelnetCfg.
+type OltDeviceConfig struct {
+	ID       string       `mapstructure:"id"`
+	Name     string       `mapstructure:"name"`
+	Model    string       `mapstructure:"model"`
+	Timezone string       `mapstructure:"timezone"` // Time zone of the OLT clock, OltCfg.Timezone when empty
+	Snmp     SnmpConfig   `mapstructure:"snmp"`
+	Telnet   TelnetConfig `mapstructure:"telnet"`
+
+	OpticalThresholds *OpticalThresholdConfig `mapstructure:"optical_thresholds"` // OltCfg.OpticalThresholds when empty
+	OID               *OltOIDConfig           `mapstructure:"oid"`                // OIDs, rack and shelf of OltCfg when empty
+}
+
+// ServerConfig describes the HTTP server of the API, durations are in seconds
+type ServerConfig struct {
+	Host              string `mapstructure:"host"`                // Host to listen on, all interfaces when empty
+	Port              string `mapstructure:"port"`                // Port to listen on (default 8081)
+	ReadTimeout       int    `mapstructure:"read_timeout"`        // Seconds to read a request including its body (default 15)
+	ReadHeaderTimeout int    `mapstructure:"read_header_timeout"` // Seconds to read the request headers (default 5)
+	WriteTimeout      int    `mapstructure:"write_timeout"`       // Seconds to write a response (default 60)
+	IdleTimeout       int    `mapstructure:"idle_timeout"`        // Seconds a keep-alive connection may stay idle (default 120)
+	TLSCertFile       string `mapstructure:"tls_cert_file"`       // PEM certificate, serve HTTPS when set with TLSKeyFile
+	TLSKeyFile        string `mapstructure:"tls_key_file"`        // PEM private key of the certificate
+	TLSClientCAFile   string `mapstructure:"tls_client_ca_file"`  // PEM CA bundle, require client certificates signed by it (mTLS)
 }
 
 type SnmpConfig struct {
-	Ip        string `mapstructure:"ip"`
-	Port      uint16 `mapstructure:"port"`
-	Community string `mapstructure:"community"`
+	Ip             string `mapstructure:"ip"`
+	Port           uint16 `mapstructure:"port"`
+	Community      string `mapstructure:"community"`
+	Version        string `mapstructure:"version"`         // "2c" (default) or "3"
+	Username       string `mapstructure:"username"`        // SNMPv3 USM user name
+	AuthProtocol   string `mapstructure:"auth_protocol"`   // SNMPv3 MD5, SHA, SHA224, SHA256, SHA384 or SHA512
+	AuthPassphrase string `mapstructure:"auth_passphrase"` // SNMPv3 authentication passphrase
+	PrivProtocol   string `mapstructure:"priv_protocol"`   // SNMPv3 DES, AES, AES192, AES256, AES192C or AES256C
+	PrivPassphrase string `mapstructure:"priv_passphrase"` // SNMPv3 privacy passphrase
+	ContextName    string `mapstructure:"context_name"`    // SNMPv3 context name
+
+	PoolSize            int  `mapstructure:"pool_size"`             // Number of sessions kept per target (default 4)
+	PoolTimeout         int  `mapstructure:"pool_timeout"`          // Seconds to wait for a free session (default 10)
+	Timeout             int  `mapstructure:"timeout"`               // Seconds before an SNMP request times out (default 3)
+	Retries             *int `mapstructure:"retries"`               // Number of retries for SNMP requests, 0 disables retries (default 1)
+	HealthCheckInterval int  `mapstructure:"health_check_interval"` // Idle seconds before a session is health checked (default 60)
+	MaxOids             int  `mapstructure:"max_oids"`              // Max varbinds per request accepted by the agent (default 60)
+	BreakerThreshold    int  `mapstructure:"breaker_threshold"`     // Consecutive timeouts before requests fail fast (default 5)
+	BreakerCooldown     int  `mapstructure:"breaker_cooldown"`      // Seconds before an unreachable agent is probed again (default 5)
+	BreakerMaxCooldown  int  `mapstructure:"breaker_max_cooldown"`  // Max seconds between probes, the cooldown doubles per failed probe (default 300)
+	MaxInFlight         int  `mapstructure:"max_in_flight"`         // Max requests in flight to the agent, others are queued (default pool size)
+	MaxQueue            int  `mapstructure:"max_queue"`             // Max queued requests before new ones are rejected (0 is unlimited)
+	MaxPDUsPerSecond    int  `mapstructure:"max_pdus_per_second"`   // Max PDUs sent to the agent per second (0 is unlimited)
+
+	RecordFile string `mapstructure:"record_file"` // Append every varbind read from the agent to this snmprec fixture
+	ReplayFile string `mapstructure:"replay_file"` // Serve this recorded walk or snmprec fixture instead of the agent
 }
 
 type TelnetConfig struct {
@@ -69,490 +90,126 @@ type RedisConfig struct {
 	PoolTimeout        int    `mapstructure:"pool_timeout"`
 }
 
-type OltConfig struct {
-	BaseOID1        string `mapstructure:"base_oid_1"`
-	BaseOID2        string `mapstructure:"base_oid_2"`
-	OnuIDNameAllPon string `mapstructure:"onu_id_name"`
-	OnuTypeAllPon   string `mapstructure:"onu_type"`
-}
-
-type Board1Pon1 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon2 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon3 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon4 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon5 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon6 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon7 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon8 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon9 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon10 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon11 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
+// CacheConfig describes the cache of the SNMP results, kept in memory while Redis is unreachable
+type CacheConfig struct {
+	MemoryMaxEntries int `mapstructure:"memory_max_entries"` // Max entries in memory, the least recently used are evicted (default 10000)
+	RetryInterval    int `mapstructure:"retry_interval"`     // Seconds before an unreachable Redis is tried again (default 30)
 }
 
-type Board1Pon12 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon13 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon14 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon15 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board1Pon16 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-type Board2Pon1 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board2Pon2 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board2Pon3 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board2Pon4 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board2Pon5 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board2Pon6 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board2Pon7 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board2Pon8 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board2Pon9 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board2Pon10 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board2Pon11 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board2Pon12 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
+type OltConfig struct {
+	OltOIDConfig       `mapstructure:",squash"`
+	OnuIDNameAllPon    string         `mapstructure:"onu_id_name"`
+	OnuTypeAllPon      string         `mapstructure:"onu_type"`
+	TableFetch         bool           `mapstructure:"table_fetch"`          // BulkWalk each column once per PON listing
+	Timezone           string         `mapstructure:"timezone"`             // IANA time zone of the OLT clocks, e.g. Asia/Jakarta (default UTC)
+	TrafficInterval    int            `mapstructure:"traffic_interval"`     // Min seconds between the interface counter samples of the traffic rates (default 60)
+	OnuTrafficInterval int            `mapstructure:"onu_traffic_interval"` // Min seconds between the ONU counter samples of the traffic rates (default 10)
+	Topology           TopologyConfig `mapstructure:"topology"`
 
-type Board2Pon13 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
+	OpticalThresholds OpticalThresholdConfig `mapstructure:"optical_thresholds"`
 }
-
-type Board2Pon14 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
+
+// OltOIDConfig holds the OIDs and the rack and shelf of the GPON boards of an OLT model, e.g. a C300 or a C320.
+// OltCfg holds the OIDs shared by all OLTs, an OLT of another model or shelf sets its own.
+type OltOIDConfig struct {
+	BaseOID1   string             `mapstructure:"base_oid_1"`
+	BaseOID2   string             `mapstructure:"base_oid_2"`
+	Rack       int                `mapstructure:"rack"`
+	Shelf      int                `mapstructure:"shelf"`
+	PonOID     PonOIDTemplateCfg  `mapstructure:"pon_oid"`
+	PortOID    PortOIDTemplateCfg `mapstructure:"port_oid"`
+	ChassisOID ChassisOIDCfg      `mapstructure:"chassis_oid"`
+}
+
+// OpticalThresholdConfig classifies the RX power of the ONUs in dBm as good, warning, critical or no signal.
+// A threshold is the lowest RX power of its class, the default is used when it is not set.
+type OpticalThresholdConfig struct {
+	GoodMin     float64                         `mapstructure:"good_min"`     // RX power at or above is good (default -25)
+	WarningMin  float64                         `mapstructure:"warning_min"`  // RX power at or above is warning (default -27)
+	CriticalMin float64                         `mapstructure:"critical_min"` // RX power at or above is critical, below is no signal (default -35)
+	NoReading   []int                           `mapstructure:"no_reading"`   // Raw values the OLT reports without a reading (default 65535)
+	OnuTypes    []OnuTypeOpticalThresholdConfig `mapstructure:"onu_types"`    // Thresholds of specific ONU types
+}
+
+// OnuTypeOpticalThresholdConfig overrides the optical thresholds for one ONU type, e.g. F660
+type OnuTypeOpticalThresholdConfig struct {
+	OnuType     string  `mapstructure:"onu_type"`
+	GoodMin     float64 `mapstructure:"good_min"`
+	WarningMin  float64 `mapstructure:"warning_min"`
+	CriticalMin float64 `mapstructure:"critical_min"`
+}
+
+// PonOIDTemplateCfg holds the per-PON OID templates.
+// Every template is appended to a base OID and may contain the placeholders
+// {if_index} (e.g. 285278465 for gpon-olt_1/1/1) and {pon_index} (e.g. 268501248 for gpon-olt_1/1/1),
+// which are calculated from the board (card slot) and PON (port) number.
+type PonOIDTemplateCfg struct {
+	OnuIDNameOID              string `mapstructure:"onu_id_name"`
+	OnuTypeOID                string `mapstructure:"onu_type"`
+	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
+	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
+	OnuOltRxPowerOID          string `mapstructure:"onu_olt_rx_power"` // Upstream power of the ONU received by the OLT
+	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
+	OnuTemperatureOID         string `mapstructure:"onu_temperature"`  // ONU optical module temperature
+	OnuVoltageOID             string `mapstructure:"onu_voltage"`      // ONU optical module supply voltage
+	OnuBiasCurrentOID         string `mapstructure:"onu_bias_current"` // ONU laser bias current
+	OnuStatusOID              string `mapstructure:"onu_status_id"`
+	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
+	OnuDescriptionOID         string `mapstructure:"onu_description"`
+	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
+	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
+	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
+	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
+	OnuUpstreamBytesOID       string `mapstructure:"onu_upstream_bytes"`     // Bytes received by the OLT from the ONU
+	OnuDownstreamBytesOID     string `mapstructure:"onu_downstream_bytes"`   // Bytes sent by the OLT to the ONU
+	OnuUpstreamPacketsOID     string `mapstructure:"onu_upstream_packets"`   // Packets received by the OLT from the ONU
+	OnuDownstreamPacketsOID   string `mapstructure:"onu_downstream_packets"` // Packets sent by the OLT to the ONU
+	OnuVendorIDOID            string `mapstructure:"onu_vendor_id"`
+	OnuEquipmentIDOID         string `mapstructure:"onu_equipment_id"` // Equipment ID reported by the ONU, e.g. F660
+	OnuHardwareVersionOID     string `mapstructure:"onu_hardware_version"`
+	OnuActiveSwVersionOID     string `mapstructure:"onu_active_software_version"`
+	OnuStandbySwVersionOID    string `mapstructure:"onu_standby_software_version"`
+	OnuMacAddressOID          string `mapstructure:"onu_mac_address"`
+	OnuEthAdminStateOID       string `mapstructure:"onu_eth_admin_state"`  // ITU-T G.988 administrative state per ETH UNI
+	OnuEthLinkStatusOID       string `mapstructure:"onu_eth_link_status"`  // Link up(1) or down(2) per ETH UNI
+	OnuEthSpeedDuplexOID      string `mapstructure:"onu_eth_speed_duplex"` // ITU-T G.988 configuration indication per ETH UNI
+}
+
+// PortOIDTemplateCfg holds the OID templates of the OLT optical module of a PON port, appended to BaseOID1.
+// The templates may contain the same placeholders as PonOIDTemplateCfg, the values are encoded like the ONU optical
+// power and transceiver columns.
+type PortOIDTemplateCfg struct {
+	ModuleTypeOID  string `mapstructure:"module_type"`
+	TxPowerOID     string `mapstructure:"tx_power"`
+	TemperatureOID string `mapstructure:"temperature"`
+	VoltageOID     string `mapstructure:"voltage"`
+	BiasCurrentOID string `mapstructure:"bias_current"`
+}
+
+// ChassisOIDCfg holds the column OIDs of the chassis tables, appended to BaseOID2.
+// Cards are indexed by rack.shelf.slot, fans and power supplies by rack.shelf.id and temperature sensors by sensor ID.
+type ChassisOIDCfg struct {
+	CardTypeOID            string `mapstructure:"card_type"`
+	CardStatusOID          string `mapstructure:"card_status"`
+	CardSoftwareVersionOID string `mapstructure:"card_software_version"`
+	CardCpuUsageOID        string `mapstructure:"card_cpu_usage"`    // Percent
+	CardMemoryUsageOID     string `mapstructure:"card_memory_usage"` // Percent
+	CardPortCountOID       string `mapstructure:"card_port_count"`   // Number of ports of the card
+	FanStatusOID           string `mapstructure:"fan_status"`
+	FanSpeedOID            string `mapstructure:"fan_speed"` // Percent of the max speed
+	TemperatureNameOID     string `mapstructure:"temperature_name"`
+	TemperatureOID         string `mapstructure:"temperature"` // °C
+	PowerStatusOID         string `mapstructure:"power_status"`
 }
 
-type Board2Pon15 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
-}
-
-type Board2Pon16 struct {
-	OnuIDNameOID              string `mapstructure:"onu_id_name"`
-	OnuTypeOID                string `mapstructure:"onu_type"`
-	OnuSerialNumberOID        string `mapstructure:"onu_serial_number"`
-	OnuRxPowerOID             string `mapstructure:"onu_rx_power"`
-	OnuTxPowerOID             string `mapstructure:"onu_tx_power"`
-	OnuStatusOID              string `mapstructure:"onu_status_id"`
-	OnuIPAddressOID           string `mapstructure:"onu_ip_address"`
-	OnuDescriptionOID         string `mapstructure:"onu_description"`
-	OnuLastOnlineOID          string `mapstructure:"onu_last_online_time"`
-	OnuLastOfflineOID         string `mapstructure:"onu_last_offline_time"`
-	OnuLastOfflineReasonOID   string `mapstructure:"onu_last_offline_reason"`
-	OnuGponOpticalDistanceOID string `mapstructure:"onu_gpon_optical_distance"`
+// TopologyConfig configures the discovery of the GPON boards and their PONs from the card table.
+// The fallback boards are used until the card table of an OLT is discovered.
+type TopologyConfig struct {
+	GponCardTypes     []string `mapstructure:"gpon_card_types"`    // Types of the cards with GPON ports (default GTGO, GTGH, GTGL, GFGL, GFGH, GFGM)
+	DiscoveryInterval int      `mapstructure:"discovery_interval"` // Seconds between the discoveries of the card table (default 300)
+	MaxOnuID          int      `mapstructure:"max_onu_id"`         // Max ONU ID of a PON (default 128)
+	FallbackBoards    []int    `mapstructure:"fallback_boards"`    // GPON boards used until the card table is discovered (default 1 and 2)
+	FallbackPons      int      `mapstructure:"fallback_pons"`      // PONs per fallback board (default 16)
 }
 
 // LoadConfig file from given path using viper
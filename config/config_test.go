@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// shippedConfigFiles are the config files selected by APP_ENV
+var shippedConfigFiles = []string{"cfg.yaml", "config-dev.yml", "config-prod.yaml"}
+
+// requiredConfigKeys are the keys every shipped config file must set
+var requiredConfigKeys = []string{
+	"ServerCfg.host",
+	"ServerCfg.port",
+	"SnmpCfg.ip",
+	"SnmpCfg.port",
+	"SnmpCfg.community",
+	"SnmpCfg.pool_size",
+	"SnmpCfg.breaker_threshold",
+	"SnmpCfg.max_in_flight",
+	"TelnetCfg.ip",
+	"TelnetCfg.port",
+	"RedisCfg.host",
+	"RedisCfg.port",
+	"CacheCfg.memory_max_entries",
+	"CacheCfg.retry_interval",
+	"OltCfg.base_oid_1",
+	"OltCfg.base_oid_2",
+	"OltCfg.timezone",
+	"OltCfg.traffic_interval",
+	"OltCfg.onu_traffic_interval",
+	"OltCfg.pon_oid.onu_id_name",
+	"OltCfg.pon_oid.onu_type",
+	"OltCfg.pon_oid.onu_serial_number",
+	"OltCfg.pon_oid.onu_rx_power",
+	"OltCfg.pon_oid.onu_status_id",
+	"OltCfg.topology.gpon_card_types",
+	"OltCfg.topology.discovery_interval",
+	"OltCfg.topology.max_onu_id",
+	"OltCfg.topology.fallback_boards",
+	"OltCfg.topology.fallback_pons",
+	"OltCfg.optical_thresholds.good_min",
+}
+
+func TestShippedConfigFiles(t *testing.T) {
+	for _, file := range shippedConfigFiles {
+		t.Run(file, func(t *testing.T) {
+			v := viper.New()
+			v.SetConfigFile(file)
+			if !assert.NoError(t, v.ReadInConfig()) {
+				return
+			}
+
+			for _, key := range requiredConfigKeys {
+				assert.True(t, v.IsSet(key), "missing key %s", key)
+			}
+
+			var cfg Config
+			assert.NoError(t, v.Unmarshal(&cfg))
+
+			// The OIDs shared by all OLTs are set from the keys of OltCfg
+			assert.NotEmpty(t, cfg.OltCfg.BaseOID1)
+			assert.NotEmpty(t, cfg.OltCfg.PonOID.OnuIDNameOID)
+		})
+	}
+}
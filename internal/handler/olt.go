@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/achyar10/snmp-olt-zte/internal/usecase"
+	"github.com/achyar10/snmp-olt-zte/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+)
+
+type OltHandlerInterface interface {
+	GetOltList(w http.ResponseWriter, r *http.Request)
+	GetSnmpBreaker(w http.ResponseWriter, r *http.Request)
+	GetSnmpLimiter(w http.ResponseWriter, r *http.Request)
+	GetOltChassis(w http.ResponseWriter, r *http.Request)
+	GetTopology(w http.ResponseWriter, r *http.Request)
+}
+
+type OltHandler struct {
+	oltUsecase usecase.OltUseCaseInterface
+}
+
+func NewOltHandler(oltUsecase usecase.OltUseCaseInterface) *OltHandler {
+	return &OltHandler{oltUsecase: oltUsecase}
+}
+
+func (o *OltHandler) GetOltList(w http.ResponseWriter, _ *http.Request) {
+	log.Info().Msg("Received a request to GetOltList")
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK,             // 200
+		Status: "OK",                      // "OK"
+		Data:   o.oltUsecase.GetOltList(), // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
+func (o *OltHandler) GetSnmpBreaker(w http.ResponseWriter, r *http.Request) {
+	oltID := chi.URLParam(r, "olt_id") // empty for the default OLT
+
+	log.Info().Msg("Received a request to GetSnmpBreaker")
+
+	breaker, err := o.oltUsecase.GetSnmpBreaker(oltID)
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   breaker,       // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
+func (o *OltHandler) GetSnmpLimiter(w http.ResponseWriter, r *http.Request) {
+	oltID := chi.URLParam(r, "olt_id") // empty for the default OLT
+
+	log.Info().Msg("Received a request to GetSnmpLimiter")
+
+	limiter, err := o.oltUsecase.GetSnmpLimiter(oltID)
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   limiter,       // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
+func (o *OltHandler) GetOltChassis(w http.ResponseWriter, r *http.Request) {
+	oltID := chi.URLParam(r, "olt_id") // empty for the default OLT
+
+	log.Info().Msg("Received a request to GetOltChassis")
+
+	// Call usecase to get data from SNMP
+	chassis, err := o.oltUsecase.GetOltChassis(r.Context(), oltID)
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get data from SNMP")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get data from snmp")) // error 500
+		return
+	}
+
+	log.Info().Msg("Successfully retrieved data from SNMP")
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   chassis,       // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
+func (o *OltHandler) GetTopology(w http.ResponseWriter, r *http.Request) {
+	oltID := chi.URLParam(r, "olt_id") // empty for the default OLT
+
+	log.Info().Msg("Received a request to GetTopology")
+
+	topology, err := o.oltUsecase.GetTopology(oltID)
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   topology,      // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
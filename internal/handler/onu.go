@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -19,6 +20,15 @@ import (
 type OnuHandlerInterface interface {
 	GetByBoardIDAndPonID(w http.ResponseWriter, r *http.Request)
 	GetByBoardIDPonIDAndOnuID(w http.ResponseWriter, r *http.Request)
+	GetByBoardIDAndPonIDV2(w http.ResponseWriter, r *http.Request)
+	GetByBoardIDPonIDAndOnuIDV2(w http.ResponseWriter, r *http.Request)
+	GetByBoardIDBelowOpticalThreshold(w http.ResponseWriter, r *http.Request)
+	GetByBoardIDBelowOpticalThresholdV2(w http.ResponseWriter, r *http.Request)
+	GetPonPort(w http.ResponseWriter, r *http.Request)
+	GetTraffic(w http.ResponseWriter, r *http.Request)
+	GetPonTraffic(w http.ResponseWriter, r *http.Request)
+	GetONUInventory(w http.ResponseWriter, r *http.Request)
+	GetONUUniPorts(w http.ResponseWriter, r *http.Request)
 	GetEmptyOnuID(w http.ResponseWriter, r *http.Request)
 	GetOnuIDAndSerialNumber(w http.ResponseWriter, r *http.Request)
 	UpdateEmptyOnuID(w http.ResponseWriter, r *http.Request)
@@ -37,26 +47,32 @@ func NewOnuHandler(ponUsecase usecase.OnuUseCaseInterface) *OnuHandler {
 
 func (o *OnuHandler) GetByBoardIDAndPonID(w http.ResponseWriter, r *http.Request) {
 
-	boardID := chi.URLParam(r, "board_id") // 1 or 2
-	ponID := chi.URLParam(r, "pon_id")     // 1 - 8
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board
 
 	boardIDInt, err := strconv.Atoi(boardID) // convert string to int
 
 	log.Info().Msg("Received a request to GetByBoardIDAndPonID")
 
-	// Validate boardIDInt value and return error 400 if boardIDInt is not 1 or 2
-	if err != nil || (boardIDInt != 1 && boardIDInt != 2) {
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
 		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
-		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be 1 or 2")) // error 400
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
 		return
 	}
 
 	ponIDInt, err := strconv.Atoi(ponID) // convert string to int
 
-	// Validate ponIDInt value and return error 400 if ponIDInt is not between 1 and 8
-	if err != nil || ponIDInt < 1 || ponIDInt > 16 {
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number
+	if err != nil || ponIDInt < 1 {
 		log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
-		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be between 1 and 16")) // error 400
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate the board and PON against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, 0) {
 		return
 	}
 
@@ -72,7 +88,17 @@ func (o *OnuHandler) GetByBoardIDAndPonID(w http.ResponseWriter, r *http.Request
 	}
 
 	// Call usecase to get data from SNMP
-	onuInfoList, err := o.ponUsecase.GetByBoardIDAndPonID(r.Context(), boardIDInt, ponIDInt)
+	onuInfoList, err := o.ponUsecase.GetByBoardIDAndPonID(r.Context(), oltID, boardIDInt, ponIDInt)
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
+
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get data from SNMP")
 		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get data from snmp")) // error 500
@@ -105,41 +131,225 @@ func (o *OnuHandler) GetByBoardIDAndPonID(w http.ResponseWriter, r *http.Request
 
 func (o *OnuHandler) GetByBoardIDPonIDAndOnuID(w http.ResponseWriter, r *http.Request) {
 
-	boardID := chi.URLParam(r, "board_id") // 1 or 2
-	ponID := chi.URLParam(r, "pon_id")     // 1 - 8
-	onuID := chi.URLParam(r, "onu_id")     // 1 - 128
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board
+	onuID := chi.URLParam(r, "onu_id")     // 1 - max ONU ID of the PON
 
 	boardIDInt, err := strconv.Atoi(boardID) // convert string to int
 
 	log.Info().Msg("Received a request to GetByBoardIDPonIDAndOnuID")
 
-	// Validate boardIDInt value and return error 400 if boardIDInt is not 1 or 2
-	if err != nil || (boardIDInt != 1 && boardIDInt != 2) {
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	ponIDInt, err := strconv.Atoi(ponID) // convert string to int
+
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number
+	if err != nil || ponIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	onuIDInt, err := strconv.Atoi(onuID) // convert string to int
+
+	// Validate onuIDInt value and return error 400 if onuIDInt is not a positive number
+	if err != nil || onuIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'onu_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'onu_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate the board, PON and ONU ID against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, onuIDInt) {
+		return
+	}
+
+	// Call usecase to get data from SNMP
+	onuInfoList, err := o.ponUsecase.GetByBoardIDPonIDAndOnuID(r.Context(), oltID, boardIDInt, ponIDInt, onuIDInt)
+
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get data from SNMP")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get data from snmp")) // error 500
+		return
+	}
+
+	log.Info().Msg("Successfully retrieved data from SNMP")
+
+	/*
+		Validate onuInfoList value
+		If onuInfoList.Board, onuInfoList.PON, and onuInfoList.ID is 0, return error 404
+		example: http://localhost:8080/board/1/pon/1/onu/129
+	*/
+
+	if onuInfoList.Board == 0 && onuInfoList.PON == 0 && onuInfoList.ID == 0 {
+		log.Error().Msg("Data not found")
+		utils.ErrorNotFound(w, fmt.Errorf("data not found")) // error 404
+		return
+	}
+
+	// Convert a result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   onuInfoList,   // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
+func (o *OnuHandler) GetByBoardIDAndPonIDV2(w http.ResponseWriter, r *http.Request) {
+
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board
+
+	boardIDInt, err := strconv.Atoi(boardID) // convert string to int
+
+	log.Info().Msg("Received a request to GetByBoardIDAndPonIDV2")
+
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	ponIDInt, err := strconv.Atoi(ponID) // convert string to int
+
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number
+	if err != nil || ponIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate the board and PON against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, 0) {
+		return
+	}
+
+	query := r.URL.Query() // Get query parameters from the request
+
+	log.Debug().Interface("query_parameters", query).Msg("Received query parameters")
+
+	//Validate query parameters and return error 400 if query parameters is not "onu_id" or empty query parameters
+	if len(query) > 0 && query["onu_id"] == nil {
+		log.Error().Msg("Invalid query parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid query parameter")) // error 400
+		return
+	}
+
+	// Call usecase to get data from SNMP
+	onuInfoList, err := o.ponUsecase.GetByBoardIDAndPonIDV2(r.Context(), oltID, boardIDInt, ponIDInt)
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get data from SNMP")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get data from snmp")) // error 500
+		return
+	}
+
+	log.Info().Msg("Successfully retrieved data from SNMP")
+
+	/*
+		Validate onuInfoList value
+		If onuInfoList is empty, return error 404
+	*/
+
+	if len(onuInfoList) == 0 {
+		log.Warn().Msg("Data not found")
+		utils.ErrorNotFound(w, fmt.Errorf("data not found")) // error 404
+		return
+	}
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   onuInfoList,   // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+
+}
+
+func (o *OnuHandler) GetByBoardIDPonIDAndOnuIDV2(w http.ResponseWriter, r *http.Request) {
+
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board
+	onuID := chi.URLParam(r, "onu_id")     // 1 - max ONU ID of the PON
+
+	boardIDInt, err := strconv.Atoi(boardID) // convert string to int
+
+	log.Info().Msg("Received a request to GetByBoardIDPonIDAndOnuIDV2")
+
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
 		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
-		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be 1 or 2")) // error 400
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
 		return
 	}
 
 	ponIDInt, err := strconv.Atoi(ponID) // convert string to int
 
-	// Validate ponIDInt value and return error 400 if ponIDInt is not between 1 and 8
-	if err != nil || ponIDInt < 1 || ponIDInt > 16 {
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number
+	if err != nil || ponIDInt < 1 {
 		log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
-		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be between 1 and 16")) // error 400
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
 		return
 	}
 
 	onuIDInt, err := strconv.Atoi(onuID) // convert string to int
 
-	// Validate onuIDInt value and return error 400 if onuIDInt is not between 1 and 128
-	if err != nil || onuIDInt < 1 || onuIDInt > 128 {
+	// Validate onuIDInt value and return error 400 if onuIDInt is not a positive number
+	if err != nil || onuIDInt < 1 {
 		log.Error().Err(err).Msg("Invalid 'onu_id' parameter")
-		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'onu_id' parameter. It must be between 1 and 128")) // error 400
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'onu_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate the board, PON and ONU ID against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, onuIDInt) {
 		return
 	}
 
 	// Call usecase to get data from SNMP
-	onuInfoList, err := o.ponUsecase.GetByBoardIDPonIDAndOnuID(boardIDInt, ponIDInt, onuIDInt)
+	onuInfoList, err := o.ponUsecase.GetByBoardIDPonIDAndOnuIDV2(r.Context(), oltID, boardIDInt, ponIDInt, onuIDInt)
+
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
 
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get data from SNMP")
@@ -171,33 +381,490 @@ func (o *OnuHandler) GetByBoardIDPonIDAndOnuID(w http.ResponseWriter, r *http.Re
 	utils.SendJSONResponse(w, http.StatusOK, response) // 200
 }
 
+func (o *OnuHandler) GetByBoardIDBelowOpticalThreshold(w http.ResponseWriter, r *http.Request) {
+
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board, empty for all PONs of the board
+	below := r.URL.Query().Get("below")    // good, warning, critical or RX power in dBm, good when empty
+
+	boardIDInt, err := strconv.Atoi(boardID) // convert string to int
+
+	log.Info().Msg("Received a request to GetByBoardIDBelowOpticalThreshold")
+
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number, 0 selects all PONs
+	ponIDInt := 0
+	if ponID != "" {
+		ponIDInt, err = strconv.Atoi(ponID) // convert string to int
+		if err != nil || ponIDInt < 1 {
+			log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
+			utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
+			return
+		}
+	}
+
+	// Validate the board and PON against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, 0) {
+		return
+	}
+
+	// Call usecase to get data from SNMP
+	onuInfoList, err := o.ponUsecase.GetByBoardIDBelowOpticalThreshold(r.Context(), oltID, boardIDInt, ponIDInt, below)
+	if errors.Is(err, usecase.ErrInvalidThreshold) {
+		log.Error().Err(err).Msg("Invalid 'below' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'below' parameter. It must be good, warning, critical or a power in dBm")) // error 400
+		return
+	}
+
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get data from SNMP")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get data from snmp")) // error 500
+		return
+	}
+
+	log.Info().Msg("Successfully retrieved data from SNMP")
+
+	// An empty list is not an error, no ONU is below the threshold
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   onuInfoList,   // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
+func (o *OnuHandler) GetByBoardIDBelowOpticalThresholdV2(w http.ResponseWriter, r *http.Request) {
+
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board, empty for all PONs of the board
+	below := r.URL.Query().Get("below")    // good, warning, critical or RX power in dBm, good when empty
+
+	boardIDInt, err := strconv.Atoi(boardID) // convert string to int
+
+	log.Info().Msg("Received a request to GetByBoardIDBelowOpticalThresholdV2")
+
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number, 0 selects all PONs
+	ponIDInt := 0
+	if ponID != "" {
+		ponIDInt, err = strconv.Atoi(ponID) // convert string to int
+		if err != nil || ponIDInt < 1 {
+			log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
+			utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
+			return
+		}
+	}
+
+	// Validate the board and PON against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, 0) {
+		return
+	}
+
+	// Call usecase to get data from SNMP
+	onuInfoList, err := o.ponUsecase.GetByBoardIDBelowOpticalThresholdV2(r.Context(), oltID, boardIDInt, ponIDInt, below)
+	if errors.Is(err, usecase.ErrInvalidThreshold) {
+		log.Error().Err(err).Msg("Invalid 'below' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'below' parameter. It must be good, warning, critical or a power in dBm")) // error 400
+		return
+	}
+
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get data from SNMP")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get data from snmp")) // error 500
+		return
+	}
+
+	log.Info().Msg("Successfully retrieved data from SNMP")
+
+	// An empty list is not an error, no ONU is below the threshold
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   onuInfoList,   // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
+func (o *OnuHandler) GetPonPort(w http.ResponseWriter, r *http.Request) {
+
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board
+
+	boardIDInt, err := strconv.Atoi(boardID) // convert string to int
+
+	log.Info().Msg("Received a request to GetPonPort")
+
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	ponIDInt, err := strconv.Atoi(ponID) // convert string to int
+
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number
+	if err != nil || ponIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate the board and PON against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, 0) {
+		return
+	}
+
+	// Call usecase to get data from SNMP
+	port, err := o.ponUsecase.GetPonPort(r.Context(), oltID, boardIDInt, ponIDInt)
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get data from SNMP")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get data from snmp")) // error 500
+		return
+	}
+
+	log.Info().Msg("Successfully retrieved data from SNMP")
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   port,          // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
+func (o *OnuHandler) GetTraffic(w http.ResponseWriter, r *http.Request) {
+
+	oltID := chi.URLParam(r, "olt_id") // empty for the default OLT
+
+	log.Info().Msg("Received a request to GetTraffic")
+
+	// Call usecase to get data from SNMP
+	traffic, err := o.ponUsecase.GetTraffic(r.Context(), oltID)
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get data from SNMP")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get data from snmp")) // error 500
+		return
+	}
+
+	log.Info().Msg("Successfully retrieved data from SNMP")
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   traffic,       // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
+func (o *OnuHandler) GetPonTraffic(w http.ResponseWriter, r *http.Request) {
+
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board
+
+	boardIDInt, err := strconv.Atoi(boardID) // convert string to int
+
+	log.Info().Msg("Received a request to GetPonTraffic")
+
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	ponIDInt, err := strconv.Atoi(ponID) // convert string to int
+
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number
+	if err != nil || ponIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate the board and PON against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, 0) {
+		return
+	}
+
+	// Call usecase to get data from SNMP
+	traffic, err := o.ponUsecase.GetPonTraffic(r.Context(), oltID, boardIDInt, ponIDInt)
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get data from SNMP")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get data from snmp")) // error 500
+		return
+	}
+
+	log.Info().Msg("Successfully retrieved data from SNMP")
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   traffic,       // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
+func (o *OnuHandler) GetONUInventory(w http.ResponseWriter, r *http.Request) {
+
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board
+
+	boardIDInt, err := strconv.Atoi(boardID) // convert string to int
+
+	log.Info().Msg("Received a request to GetONUInventory")
+
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	ponIDInt, err := strconv.Atoi(ponID) // convert string to int
+
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number
+	if err != nil || ponIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate the board and PON against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, 0) {
+		return
+	}
+
+	// Call usecase to get data from SNMP
+	inventory, err := o.ponUsecase.GetONUInventory(r.Context(), oltID, boardIDInt, ponIDInt)
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get data from SNMP")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get data from snmp")) // error 500
+		return
+	}
+
+	log.Info().Msg("Successfully retrieved data from SNMP")
+
+	// Convert result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   inventory,     // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
+func (o *OnuHandler) GetONUUniPorts(w http.ResponseWriter, r *http.Request) {
+
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board
+	onuID := chi.URLParam(r, "onu_id")     // 1 - max ONU ID of the PON
+
+	boardIDInt, err := strconv.Atoi(boardID) // convert string to int
+
+	log.Info().Msg("Received a request to GetONUUniPorts")
+
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	ponIDInt, err := strconv.Atoi(ponID) // convert string to int
+
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number
+	if err != nil || ponIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	onuIDInt, err := strconv.Atoi(onuID) // convert string to int
+
+	// Validate onuIDInt value and return error 400 if onuIDInt is not a positive number
+	if err != nil || onuIDInt < 1 {
+		log.Error().Err(err).Msg("Invalid 'onu_id' parameter")
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'onu_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate the board, PON and ONU ID against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, onuIDInt) {
+		return
+	}
+
+	// Call usecase to get data from SNMP
+	uni, err := o.ponUsecase.GetONUUniPorts(r.Context(), oltID, boardIDInt, ponIDInt, onuIDInt)
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get data from SNMP")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get data from snmp or telnet")) // error 500
+		return
+	}
+
+	log.Info().Msg("Successfully retrieved data from SNMP")
+
+	// The ONU does not exist, return error 404
+	if uni.Board == 0 && uni.PON == 0 && uni.ID == 0 {
+		log.Error().Msg("Data not found")
+		utils.ErrorNotFound(w, fmt.Errorf("data not found")) // error 404
+		return
+	}
+
+	// Convert a result to JSON format according to WebResponse structure
+	response := utils.WebResponse{
+		Code:   http.StatusOK, // 200
+		Status: "OK",          // "OK"
+		Data:   uni,           // data
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response) // 200
+}
+
 func (o *OnuHandler) GetEmptyOnuID(w http.ResponseWriter, r *http.Request) {
 
-	boardID := chi.URLParam(r, "board_id") // 1 or 2
-	ponID := chi.URLParam(r, "pon_id")     // 1 - 8
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board
 
 	boardIDInt, err := strconv.Atoi(boardID) // convert string to int
 
 	log.Info().Msg("Received a request to GetEmptyOnuID")
 
-	// Validate boardIDInt value and return error 400 if boardIDInt is not 1 or 2
-	if err != nil || (boardIDInt != 1 && boardIDInt != 2) {
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
 		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
-		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be 1 or 2")) // error 400
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
 		return
 	}
 
 	ponIDInt, err := strconv.Atoi(ponID) // convert string to int
 
-	// Validate ponIDInt value and return error 400 if ponIDInt is not between 1 and 8
-	if err != nil || ponIDInt < 1 || ponIDInt > 16 {
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number
+	if err != nil || ponIDInt < 1 {
 		log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
-		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be between 1 and 16")) // error 400
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate the board and PON against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, 0) {
 		return
 	}
 
 	// Call usecase to get data from SNMP
-	onuIDEmptyList, err := o.ponUsecase.GetEmptyOnuID(r.Context(), boardIDInt, ponIDInt)
+	onuIDEmptyList, err := o.ponUsecase.GetEmptyOnuID(r.Context(), oltID, boardIDInt, ponIDInt)
+
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
 
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get data from SNMP")
@@ -219,31 +886,47 @@ func (o *OnuHandler) GetEmptyOnuID(w http.ResponseWriter, r *http.Request) {
 
 func (o *OnuHandler) GetOnuIDAndSerialNumber(w http.ResponseWriter, r *http.Request) {
 
-	boardID := chi.URLParam(r, "board_id") // 1 or 2
-	ponID := chi.URLParam(r, "pon_id")     // 1 - 8
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board
 
 	boardIDInt, err := strconv.Atoi(boardID) // convert string to int
 
 	log.Info().Msg("Received a request to GetOnuSerialNumber")
 
-	// Validate boardIDInt value and return error 400 if boardIDInt is not 1 or 2
-	if err != nil || (boardIDInt != 1 && boardIDInt != 2) {
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
 		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
-		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be 1 or 2")) // error 400
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
 		return
 	}
 
 	ponIDInt, err := strconv.Atoi(ponID) // convert string to int
 
-	// Validate ponIDInt value and return error 400 if ponIDInt is not between 1 and 8
-	if err != nil || ponIDInt < 1 || ponIDInt > 16 {
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number
+	if err != nil || ponIDInt < 1 {
 		log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
-		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be between 1 and 16")) // error 400
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate the board and PON against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, 0) {
 		return
 	}
 
 	// Call usecase to get Serial Number from SNMP
-	onuSerialNumber, err := o.ponUsecase.GetOnuIDAndSerialNumber(boardIDInt, ponIDInt)
+	onuSerialNumber, err := o.ponUsecase.GetOnuIDAndSerialNumber(r.Context(), oltID, boardIDInt, ponIDInt)
+
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
 
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get data from SNMP")
@@ -264,31 +947,47 @@ func (o *OnuHandler) GetOnuIDAndSerialNumber(w http.ResponseWriter, r *http.Requ
 }
 
 func (o *OnuHandler) UpdateEmptyOnuID(w http.ResponseWriter, r *http.Request) {
-	boardID := chi.URLParam(r, "board_id") // 1 or 2
-	ponID := chi.URLParam(r, "pon_id")     // 1 - 8
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board
 
 	boardIDInt, err := strconv.Atoi(boardID) // convert string to int
 
 	log.Info().Msg("Received a request to UpdateEmptyOnuID")
 
-	// Validate boardIDInt value and return error 400 if boardIDInt is not 1 or 2
-	if err != nil || (boardIDInt != 1 && boardIDInt != 2) {
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
 		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
-		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be 0 or 1")) // error 400
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
 		return
 	}
 
 	ponIDInt, err := strconv.Atoi(ponID) // convert string to int
 
-	// Validate ponIDInt value and return error 400 if ponIDInt is not between 1 and 8
-	if err != nil || ponIDInt < 1 || ponIDInt > 16 {
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number
+	if err != nil || ponIDInt < 1 {
 		log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
-		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be between 1 and 16")) // error 400
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate the board and PON against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, 0) {
 		return
 	}
 
 	// Call usecase to get data from SNMP
-	err = o.ponUsecase.UpdateEmptyOnuID(r.Context(), boardIDInt, ponIDInt)
+	err = o.ponUsecase.UpdateEmptyOnuID(r.Context(), oltID, boardIDInt, ponIDInt)
+
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
+		return
+	}
 
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get data from SNMP")
@@ -310,8 +1009,9 @@ func (o *OnuHandler) UpdateEmptyOnuID(w http.ResponseWriter, r *http.Request) {
 
 func (o *OnuHandler) GetByBoardIDAndPonIDWithPaginate(w http.ResponseWriter, r *http.Request) {
 
-	boardID := chi.URLParam(r, "board_id") // 1 or 2
-	ponID := chi.URLParam(r, "pon_id")     // 1 - 8
+	oltID := chi.URLParam(r, "olt_id")     // empty for the default OLT
+	boardID := chi.URLParam(r, "board_id") // GPON board (card slot) of the OLT
+	ponID := chi.URLParam(r, "pon_id")     // PON of the board
 
 	// Get page and page size parameters from the request
 	pageIndex, pageSize := pagination.GetPaginationParametersFromRequest(r)
@@ -320,24 +1020,45 @@ func (o *OnuHandler) GetByBoardIDAndPonIDWithPaginate(w http.ResponseWriter, r *
 
 	log.Info().Msg("Received a request to GetByBoardIDAndPonIDWithPaginate")
 
-	// Validate boardIDInt value and return error 400 if boardIDInt is not 1 or 2
-	if err != nil || (boardIDInt != 1 && boardIDInt != 2) {
+	// Validate boardIDInt value and return error 400 if boardIDInt is not a positive number
+	if err != nil || boardIDInt < 1 {
 		log.Error().Err(err).Msg("Invalid 'board_id' parameter")
-		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be 1 or 2")) // error 400
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'board_id' parameter. It must be a positive number")) // error 400
 		return
 	}
 
 	ponIDInt, err := strconv.Atoi(ponID) // convert string to int
 
-	// Validate ponIDInt value and return error 400 if ponIDInt is not between 1 and 8
-	if err != nil || ponIDInt < 1 || ponIDInt > 16 {
+	// Validate ponIDInt value and return error 400 if ponIDInt is not a positive number
+	if err != nil || ponIDInt < 1 {
 		log.Error().Err(err).Msg("Invalid 'pon_id' parameter")
-		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be between 1 and 16")) // error 400
+		utils.ErrorBadRequest(w, fmt.Errorf("invalid 'pon_id' parameter. It must be a positive number")) // error 400
+		return
+	}
+
+	// Validate the board and PON against the topology of the OLT and return error 400 if they are not installed
+	if !o.validateTopology(w, oltID, boardIDInt, ponIDInt, 0) {
+		return
+	}
+
+	item, count, err := o.ponUsecase.GetByBoardIDAndPonIDWithPagination(r.Context(), oltID, boardIDInt, ponIDInt,
+		pageIndex, pageSize)
+
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return
+	}
+
+	if writeSnmpError(w, err) {
 		return
 	}
 
-	item, count := o.ponUsecase.GetByBoardIDAndPonIDWithPagination(boardIDInt, ponIDInt, pageIndex,
-		pageSize)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get data from SNMP")
+		utils.ErrorInternalServerError(w, fmt.Errorf("cannot get data from snmp")) // error 500
+		return
+	}
 
 	/*
 		Validate item value
@@ -391,25 +1112,34 @@ func (o *OnuHandler) ActivateONU(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get ONU ID: dari payload atau dari fungsi available
-	var onuID int
+	// Validate onu value and return error 400 if onu is provided and not a positive number
+	onuID := 0
 	if payload.Onu != nil {
-		onuID = *payload.Onu
-	} else {
-		available, err := utils.GetAvailableONUOnly(payload.OLTIndex, 128)
-		if err != nil || len(available) == 0 {
-			log.Error().Err(err).Msg("No available ONU found")
-			utils.ErrorInternalServerError(w, fmt.Errorf("no available ONU found"))
+		if *payload.Onu <= 0 {
+			log.Error().Msg("Invalid 'onu' field")
+			utils.ErrorBadRequest(w, fmt.Errorf("invalid 'onu' field. It must be a positive number")) // error 400
 			return
 		}
-		onuID = available[0].ID
+		onuID = *payload.Onu
 	}
 
-	// Build command
-	cmd := utils.BuildZTERegisterCommand(slot, port, payload.Region, payload.SerialNumber, payload.Code, onuID)
+	// Validate that the slot is a GPON board, the port a PON of the board and the ONU ID within the PON
+	if !o.validateTopology(w, chi.URLParam(r, "olt_id"), slot, port, onuID) {
+		return
+	}
 
-	// Run telnet command
-	resp, err := utils.RunTelnetCommand(cmd)
+	// Register ONU via Telnet, using the first available ONU ID if it is not provided
+	onuID, resp, err := o.ponUsecase.ActivateONU(r.Context(), chi.URLParam(r, "olt_id"), slot, port, payload)
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found"))
+		return
+	}
+	if errors.Is(err, usecase.ErrNoAvailableOnu) {
+		log.Error().Err(err).Msg("No available ONU found")
+		utils.ErrorInternalServerError(w, fmt.Errorf("no available ONU found"))
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Msg("Activation failed via Telnet")
 		utils.ErrorInternalServerError(w, fmt.Errorf("activation failed"))
@@ -455,14 +1185,18 @@ func (o *OnuHandler) ActivateONU(w http.ResponseWriter, r *http.Request) {
 func (o *OnuHandler) GetUnactivatedONU(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
 
-	output, err := utils.RunTelnetCommand("show pon onu u")
+	onuItems, err := o.ponUsecase.GetUnactivatedONU(r.Context(), chi.URLParam(r, "olt_id"))
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found"))
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to execute Telnet command")
 		utils.ErrorInternalServerError(w, fmt.Errorf("failed to execute telnet command: %v", err))
 		return
 	}
 
-	onuItems := utils.ParseONULineOutput(output)
 	duration := time.Since(start).Seconds()
 
 	response := utils.WebResponse{
@@ -476,3 +1210,22 @@ func (o *OnuHandler) GetUnactivatedONU(w http.ResponseWriter, r *http.Request) {
 
 	utils.SendJSONResponse(w, http.StatusOK, response)
 }
+
+// validateTopology validates a board, PON and ONU ID against the topology of the OLT, 0 skips the PON or ONU ID.
+// It writes error 400 for an ID that is not installed or error 404 for an unknown OLT.
+func (o *OnuHandler) validateTopology(w http.ResponseWriter, oltID string, boardID, ponID, onuID int) bool {
+	err := o.ponUsecase.ValidateTopology(oltID, boardID, ponID, onuID)
+	if errors.Is(err, usecase.ErrOltNotFound) {
+		log.Error().Err(err).Msg("Invalid 'olt_id' parameter")
+		utils.ErrorNotFound(w, fmt.Errorf("olt not found")) // error 404
+		return false
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid topology parameter")
+		utils.ErrorBadRequest(w, err) // error 400
+		return false
+	}
+
+	return true
+}
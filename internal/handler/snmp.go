@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/achyar10/snmp-olt-zte/internal/utils"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/rs/zerolog/log"
+)
+
+// writeSnmpError writes error 503 when the SNMP agent is unreachable or busy, it returns false for the other errors
+func writeSnmpError(w http.ResponseWriter, err error) bool {
+	if errors.Is(err, snmp.ErrCircuitOpen) {
+		log.Error().Err(err).Msg("SNMP agent unreachable")
+		utils.ErrorServiceUnavailable(w, fmt.Errorf("snmp agent unreachable")) // error 503
+		return true
+	}
+
+	if errors.Is(err, snmp.ErrLimiterQueueFull) || errors.Is(err, snmp.ErrPoolTimeout) {
+		log.Error().Err(err).Msg("SNMP agent busy")
+		utils.ErrorServiceUnavailable(w, fmt.Errorf("snmp agent busy, try again later")) // error 503
+		return true
+	}
+
+	return false
+}
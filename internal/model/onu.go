@@ -1,12 +1,18 @@
 package model
 
+import "time"
+
 type OltConfig struct {
 	BaseOID                   string
 	OnuIDNameOID              string
 	OnuTypeOID                string
 	OnuSerialNumberOID        string
 	OnuRxPowerOID             string
+	OnuOltRxPowerOID          string
 	OnuTxPowerOID             string
+	OnuTemperatureOID         string
+	OnuVoltageOID             string
+	OnuBiasCurrentOID         string
 	OnuStatusOID              string
 	OnuIPAddressOID           string
 	OnuDescriptionOID         string
@@ -14,6 +20,186 @@ type OltConfig struct {
 	OnuLastOfflineOID         string
 	OnuLastOfflineReasonOID   string
 	OnuGponOpticalDistanceOID string
+	OnuUpstreamBytesOID       string
+	OnuDownstreamBytesOID     string
+	OnuUpstreamPacketsOID     string
+	OnuDownstreamPacketsOID   string
+	OnuVendorIDOID            string
+	OnuEquipmentIDOID         string
+	OnuHardwareVersionOID     string
+	OnuActiveSwVersionOID     string
+	OnuStandbySwVersionOID    string
+	OnuMacAddressOID          string
+	OnuEthAdminStateOID       string
+	OnuEthLinkStatusOID       string
+	OnuEthSpeedDuplexOID      string
+}
+
+type Olt struct {
+	ID       string `json:"olt_id"`
+	Name     string `json:"name"`
+	Model    string `json:"model"`
+	Host     string `json:"host"`
+	Timezone string `json:"timezone"`
+}
+
+type SnmpBreaker struct {
+	OltID               string     `json:"olt_id"`
+	State               string     `json:"state"`
+	ConsecutiveTimeouts int        `json:"consecutive_timeouts"`
+	Trips               int        `json:"trips"`
+	CooldownSeconds     int        `json:"cooldown_seconds"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	RetryAt             *time.Time `json:"retry_at,omitempty"`
+}
+
+type SnmpLimiter struct {
+	OltID              string  `json:"olt_id"`
+	MaxInFlight        int     `json:"max_in_flight"`
+	InFlight           int     `json:"in_flight"`
+	MaxQueue           int     `json:"max_queue"`
+	Queued             int     `json:"queued"`
+	MaxPDUsPerSecond   float64 `json:"max_pdus_per_second"`
+	Requests           int64   `json:"requests"`
+	QueuedRequests     int64   `json:"queued_requests"`
+	RejectedRequests   int64   `json:"rejected_requests"`
+	AvgQueueTimeMs     float64 `json:"avg_queue_time_ms"`
+	MaxQueueTimeMs     float64 `json:"max_queue_time_ms"`
+	PDUs               int64   `json:"pdus"`
+	RateLimitedPDUs    int64   `json:"rate_limited_pdus"`
+	AvgRateLimitWaitMs float64 `json:"avg_rate_limit_wait_ms"`
+}
+
+// OltChassis is the system information and the chassis health of an OLT, values that are not available are null
+type OltChassis struct {
+	OltID              string                 `json:"olt_id"`
+	SysDescr           string                 `json:"sys_descr"`
+	SysName            string                 `json:"sys_name"`
+	SysUptimeSeconds   *int64                 `json:"sys_uptime_seconds"`
+	SysUptime          string                 `json:"sys_uptime"`
+	Cards              []OltCard              `json:"cards"`
+	Fans               []OltFan               `json:"fans"`
+	TemperatureSensors []OltTemperatureSensor `json:"temperature_sensors"`
+	PowerSupplies      []OltPowerSupply       `json:"power_supplies"`
+}
+
+// OltCard is a card in a slot of the OLT chassis
+type OltCard struct {
+	Rack               int    `json:"rack"`
+	Shelf              int    `json:"shelf"`
+	Slot               int    `json:"slot"`
+	Type               string `json:"type"`   // e.g. GTGO, SMXA
+	Status             string `json:"status"` // e.g. inService, offline or unknown
+	SoftwareVersion    string `json:"software_version"`
+	CpuUsagePercent    *int   `json:"cpu_usage_percent"`
+	MemoryUsagePercent *int   `json:"memory_usage_percent"`
+}
+
+// OltFan is a fan of the OLT chassis
+type OltFan struct {
+	Fan          int    `json:"fan"`
+	Status       string `json:"status"` // normal, fault, absent or unknown
+	SpeedPercent *int   `json:"speed_percent"`
+}
+
+// OltTemperatureSensor is a temperature sensor of the OLT chassis
+type OltTemperatureSensor struct {
+	Sensor      int    `json:"sensor"`
+	Name        string `json:"name"`
+	Temperature *int   `json:"temperature_celsius"`
+}
+
+// OltPowerSupply is a power supply of the OLT chassis
+type OltPowerSupply struct {
+	PSU    int    `json:"psu"`
+	Status string `json:"status"` // normal, fault, absent or unknown
+}
+
+// OltTopology is the GPON boards of an OLT and their PONs. Until the card table is discovered the fallback boards of
+// the configuration are used, discovered is false and discovered_at is null.
+type OltTopology struct {
+	OltID        string     `json:"olt_id"`
+	Discovered   bool       `json:"discovered"`
+	DiscoveredAt *time.Time `json:"discovered_at"`
+	MaxOnuID     int        `json:"max_onu_id"`
+	Boards       []OltBoard `json:"boards"`
+}
+
+// OltBoard is a GPON board (card slot) of an OLT with its PONs numbered 1 to pons
+type OltBoard struct {
+	Board  int    `json:"board"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Pons   int    `json:"pons"`
+}
+
+// Signal quality classes of the RX power of an ONU
+const (
+	SignalQualityGood      = "good"
+	SignalQualityWarning   = "warning"
+	SignalQualityCritical  = "critical"
+	SignalQualityNoSignal  = "no_signal"
+	SignalQualityNoReading = "no_reading" // The OLT has no reading, e.g. of an offline ONU
+)
+
+// PonPort is the status and OLT optical module of a PON port, values that are not available are null
+type PonPort struct {
+	Board          int      `json:"board"`
+	PON            int      `json:"pon"`
+	Name           string   `json:"name"`
+	IfIndex        int      `json:"if_index"`
+	AdminStatus    string   `json:"admin_status"`
+	OperStatus     string   `json:"oper_status"`
+	ModuleType     string   `json:"module_type"`
+	TXPower        *float64 `json:"tx_power_dbm"`
+	Temperature    *float64 `json:"temperature_celsius"`
+	Voltage        *float64 `json:"voltage_v"`
+	BiasCurrent    *float64 `json:"bias_current_ma"`
+	RegisteredOnus int      `json:"registered_onus"`
+	OnlineOnus     int      `json:"online_onus"`
+	OfflineOnus    int      `json:"offline_onus"`
+}
+
+// Interface types of the IF-MIB traffic
+const (
+	InterfaceTypeGpon   = "gpon"
+	InterfaceTypeUplink = "uplink"
+)
+
+// InterfaceTraffic is the IF-MIB traffic of a GPON or uplink interface. The rates are calculated over the interval
+// since an earlier sample of the counters, they are null without an earlier sample or after a counter reset.
+type InterfaceTraffic struct {
+	Name            string    `json:"name"`
+	IfIndex         int       `json:"if_index"`
+	Type            string    `json:"type"`
+	Board           int       `json:"board,omitempty"`
+	PON             int       `json:"pon,omitempty"`
+	SpeedMbps       *uint64   `json:"speed_mbps"`
+	InOctets        uint64    `json:"in_octets"`
+	OutOctets       uint64    `json:"out_octets"`
+	InErrors        uint64    `json:"in_errors"`
+	OutErrors       uint64    `json:"out_errors"`
+	InDiscards      uint64    `json:"in_discards"`
+	OutDiscards     uint64    `json:"out_discards"`
+	InBps           *float64  `json:"in_bps"`
+	OutBps          *float64  `json:"out_bps"`
+	InUtilization   *float64  `json:"in_utilization_percent"`
+	OutUtilization  *float64  `json:"out_utilization_percent"`
+	IntervalSeconds *float64  `json:"interval_seconds"`
+	SampledAt       time.Time `json:"sampled_at"`
+}
+
+// TrafficSample is a sample of the IF-MIB counters of an interface, kept to calculate the traffic rates
+type TrafficSample struct {
+	Time        time.Time `json:"time"`
+	InOctets    uint64    `json:"in_octets"`
+	OutOctets   uint64    `json:"out_octets"`
+	InErrors    uint64    `json:"in_errors"`
+	OutErrors   uint64    `json:"out_errors"`
+	InDiscards  uint64    `json:"in_discards"`
+	OutDiscards uint64    `json:"out_discards"`
+	InPackets   uint64    `json:"in_packets,omitempty"`
+	OutPackets  uint64    `json:"out_packets,omitempty"`
 }
 
 type ONUInfo struct {
@@ -22,34 +208,173 @@ type ONUInfo struct {
 }
 
 type ONUInfoPerBoard struct {
+	Board         int    `json:"board"`
+	PON           int    `json:"pon"`
+	ID            int    `json:"onu_id"`
+	Name          string `json:"name"`
+	OnuType       string `json:"onu_type"`
+	SerialNumber  string `json:"serial_number"`
+	RXPower       string `json:"rx_power"`
+	OltRXPower    string `json:"olt_rx_power"`
+	SignalQuality string `json:"signal_quality"`
+	Status        string `json:"status"`
+}
+
+type ONUCustomerInfo struct {
+	Board                int            `json:"board"`
+	PON                  int            `json:"pon"`
+	ID                   int            `json:"onu_id"`
+	Name                 string         `json:"name"`
+	Description          string         `json:"description"`
+	OnuType              string         `json:"onu_type"`
+	SerialNumber         string         `json:"serial_number"`
+	RXPower              string         `json:"rx_power"`
+	OltRXPower           string         `json:"olt_rx_power"`
+	SignalQuality        string         `json:"signal_quality"`
+	TXPower              string         `json:"tx_power"`
+	Status               string         `json:"status"`
+	IPAddress            string         `json:"ip_address"`
+	LastOnline           string         `json:"last_online"`
+	LastOffline          string         `json:"last_offline"`
+	Uptime               string         `json:"uptime"`
+	LastDownTimeDuration string         `json:"last_down_time_duration"`
+	LastOfflineReason    string         `json:"offline_reason"`
+	GponOpticalDistance  string         `json:"gpon_optical_distance"`
+	Transceiver          ONUTransceiver `json:"transceiver"`
+	Traffic              ONUTraffic     `json:"traffic"`
+	Inventory            ONUInventory   `json:"inventory"`
+}
+
+// ONUTransceiver holds the DDM values of the optical module of an ONU
+type ONUTransceiver struct {
+	Temperature string `json:"temperature"`  // °C
+	Voltage     string `json:"voltage"`      // V
+	BiasCurrent string `json:"bias_current"` // mA
+}
+
+// ONUTraffic holds the performance counters of an ONU and their rates since an earlier sample, upstream is received
+// by the OLT from the ONU
+type ONUTraffic struct {
+	UpstreamBytes     string `json:"upstream_bytes"`
+	DownstreamBytes   string `json:"downstream_bytes"`
+	UpstreamPackets   string `json:"upstream_packets"`
+	DownstreamPackets string `json:"downstream_packets"`
+	UpstreamRate      string `json:"upstream_rate"`   // bit/s
+	DownstreamRate    string `json:"downstream_rate"` // bit/s
+	Interval          string `json:"interval"`        // Seconds between the samples of the rates
+}
+
+// ONUInfoPerBoardV2 is the typed representation of ONUInfoPerBoard, values that are not available are null
+type ONUInfoPerBoardV2 struct {
+	Board         int      `json:"board"`
+	PON           int      `json:"pon"`
+	ID            int      `json:"onu_id"`
+	Name          string   `json:"name"`
+	OnuType       string   `json:"onu_type"`
+	SerialNumber  string   `json:"serial_number"`
+	RXPower       *float64 `json:"rx_power_dbm"`
+	OltRXPower    *float64 `json:"olt_rx_power_dbm"`
+	SignalQuality string   `json:"signal_quality"`
+	StatusCode    *int     `json:"status_code"`
+	Status        string   `json:"status"`
+}
+
+// ONUCustomerInfoV2 is the typed representation of ONUCustomerInfo, values that are not available are null
+type ONUCustomerInfoV2 struct {
+	Board                 int              `json:"board"`
+	PON                   int              `json:"pon"`
+	ID                    int              `json:"onu_id"`
+	Name                  string           `json:"name"`
+	Description           string           `json:"description"`
+	OnuType               string           `json:"onu_type"`
+	SerialNumber          string           `json:"serial_number"`
+	RXPower               *float64         `json:"rx_power_dbm"`
+	OltRXPower            *float64         `json:"olt_rx_power_dbm"`
+	SignalQuality         string           `json:"signal_quality"`
+	TXPower               *float64         `json:"tx_power_dbm"`
+	StatusCode            *int             `json:"status_code"`
+	Status                string           `json:"status"`
+	IPAddress             string           `json:"ip_address"`
+	LastOnline            *time.Time       `json:"last_online"`
+	LastOffline           *time.Time       `json:"last_offline"`
+	LastOfflineReasonCode *int             `json:"offline_reason_code"`
+	LastOfflineReason     string           `json:"offline_reason"`
+	GponOpticalDistance   *int             `json:"gpon_optical_distance_m"`
+	UptimeSeconds         *int64           `json:"uptime_seconds"`
+	LastDownTimeSeconds   *int64           `json:"last_down_time_seconds"`
+	Transceiver           ONUTransceiverV2 `json:"transceiver"`
+	Traffic               ONUTrafficV2     `json:"traffic"`
+	Inventory             ONUInventory     `json:"inventory"`
+}
+
+// ONUTransceiverV2 is the typed representation of ONUTransceiver, values that are not available are null
+type ONUTransceiverV2 struct {
+	Temperature *float64 `json:"temperature_celsius"`
+	Voltage     *float64 `json:"voltage_v"`
+	BiasCurrent *float64 `json:"bias_current_ma"`
+}
+
+// ONUTrafficV2 is the typed representation of ONUTraffic. The rates are null without an earlier sample of the
+// counters or after a counter reset.
+type ONUTrafficV2 struct {
+	UpstreamBytes     *uint64  `json:"upstream_bytes"`
+	DownstreamBytes   *uint64  `json:"downstream_bytes"`
+	UpstreamPackets   *uint64  `json:"upstream_packets"`
+	DownstreamPackets *uint64  `json:"downstream_packets"`
+	UpstreamBps       *float64 `json:"upstream_bps"`
+	DownstreamBps     *float64 `json:"downstream_bps"`
+	UpstreamPps       *float64 `json:"upstream_pps"`
+	DownstreamPps     *float64 `json:"downstream_pps"`
+	IntervalSeconds   *float64 `json:"interval_seconds"`
+	Counter32         bool     `json:"-"` // The agent reports Counter32 values, which wrap after 2^32-1
+}
+
+// ONUInventory holds the equipment attributes reported by an ONU, empty when they are not available
+type ONUInventory struct {
+	VendorID               string `json:"vendor_id"`
+	EquipmentID            string `json:"equipment_id"`
+	HardwareVersion        string `json:"hardware_version"`
+	ActiveSoftwareVersion  string `json:"active_software_version"`
+	StandbySoftwareVersion string `json:"standby_software_version"`
+	MacAddress             string `json:"mac_address"`
+}
+
+// Sources of the ONU data read via SNMP or, when the MIB does not have it, from the CLI over telnet
+const (
+	SourceSnmp   = "snmp"
+	SourceTelnet = "telnet"
+)
+
+// ONUInventoryItem is the inventory of an ONU of a PON, the source is empty when the inventory is not available
+type ONUInventoryItem struct {
 	Board        int    `json:"board"`
 	PON          int    `json:"pon"`
 	ID           int    `json:"onu_id"`
 	Name         string `json:"name"`
 	OnuType      string `json:"onu_type"`
 	SerialNumber string `json:"serial_number"`
-	RXPower      string `json:"rx_power"`
 	Status       string `json:"status"`
+	ONUInventory
+	Source string `json:"source"`
 }
 
-type ONUCustomerInfo struct {
-	Board                int    `json:"board"`
-	PON                  int    `json:"pon"`
-	ID                   int    `json:"onu_id"`
-	Name                 string `json:"name"`
-	Description          string `json:"description"`
-	OnuType              string `json:"onu_type"`
-	SerialNumber         string `json:"serial_number"`
-	RXPower              string `json:"rx_power"`
-	TXPower              string `json:"tx_power"`
-	Status               string `json:"status"`
-	IPAddress            string `json:"ip_address"`
-	LastOnline           string `json:"last_online"`
-	LastOffline          string `json:"last_offline"`
-	Uptime               string `json:"uptime"`
-	LastDownTimeDuration string `json:"last_down_time_duration"`
-	LastOfflineReason    string `json:"offline_reason"`
-	GponOpticalDistance  string `json:"gpon_optical_distance"`
+// ONUUniPort is the state of an ETH UNI port of an ONU, unknown when it is not available
+type ONUUniPort struct {
+	Port       int    `json:"port"`
+	Name       string `json:"name"`
+	Link       string `json:"link"`        // up, down or unknown
+	Speed      string `json:"speed"`       // e.g. 1000M, empty without link or with auto-negotiation pending
+	Duplex     string `json:"duplex"`      // full or half, empty without link
+	AdminState string `json:"admin_state"` // enabled, disabled or unknown
+}
+
+// ONUUniPorts are the ETH UNI ports of an ONU, the source is empty when the ports are not available
+type ONUUniPorts struct {
+	Board  int          `json:"board"`
+	PON    int          `json:"pon"`
+	ID     int          `json:"onu_id"`
+	Ports  []ONUUniPort `json:"ports"`
+	Source string       `json:"source"`
 }
 
 type OnuID struct {
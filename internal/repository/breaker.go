@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/gosnmp/gosnmp"
+)
+
+// breakerRepository is a struct that implements SnmpRepositoryInterface, failing fast while the agent is unreachable
+type breakerRepository struct {
+	repo    SnmpRepositoryInterface // Repository the requests are sent through
+	breaker *snmp.Breaker           // Circuit breaker of the target
+}
+
+// NewBreakerRepository is a constructor function to wrap an SNMP repository with a circuit breaker
+func NewBreakerRepository(repo SnmpRepositoryInterface, breaker *snmp.Breaker) SnmpRepositoryInterface {
+	return &breakerRepository{
+		repo:    repo,
+		breaker: breaker,
+	}
+}
+
+// Get to get SNMP data for the given OIDs, unless the circuit breaker is open
+func (r *breakerRepository) Get(ctx context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	var result *gosnmp.SnmpPacket
+
+	err := r.breaker.Do(ctx, func() error {
+		var err error
+		result, err = r.repo.Get(ctx, oids)
+		return err
+	})
+	return result, err
+}
+
+// GetBatch to get SNMP data for any number of OIDs, unless the circuit breaker is open
+func (r *breakerRepository) GetBatch(ctx context.Context, oids []string) ([]gosnmp.SnmpPDU, error) {
+	var variables []gosnmp.SnmpPDU
+
+	err := r.breaker.Do(ctx, func() error {
+		var err error
+		variables, err = r.repo.GetBatch(ctx, oids)
+		return err
+	})
+	return variables, err
+}
+
+// Walk for SNMP Walk to get all OIDs under the given OID, unless the circuit breaker is open
+func (r *breakerRepository) Walk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	return r.breaker.Do(ctx, func() error {
+		return r.repo.Walk(ctx, oid, walkFunc)
+	})
+}
+
+// BulkWalk for SNMP Walk with GetBulk requests, unless the circuit breaker is open
+func (r *breakerRepository) BulkWalk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	return r.breaker.Do(ctx, func() error {
+		return r.repo.BulkWalk(ctx, oid, walkFunc)
+	})
+}
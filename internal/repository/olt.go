@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+)
+
+// DefaultOltID is the ID of the OLT used when no OLT ID is given
+const DefaultOltID = "default"
+
+// OltRepositoryInterface is an interface that represents the OLT registry contract
+type OltRepositoryInterface interface {
+	List() []model.Olt                          // List all registered OLTs
+	Get(oltID string) (*OltConnection, bool)    // Get the connection of an OLT, the first OLT if oltID is empty
+	Register(olt model.Olt, conn OltConnection) // Register an OLT with its SNMP and telnet repositories
+}
+
+// OltConnection groups the repositories used to access one OLT
+type OltConnection struct {
+	Olt      model.Olt                     // OLT information
+	Snmp     SnmpRepositoryInterface       // SNMP repository of the OLT
+	Breaker  *snmp.Breaker                 // Circuit breaker of the SNMP agent of the OLT
+	Limiter  *snmp.Limiter                 // Limiter of the SNMP requests to the OLT
+	Telnet   TelnetRepositoryInterface     // Telnet repository of the OLT
+	Clock    *time.Location                // Time zone of the OLT clock, for timestamps without UTC offset
+	Optical  config.OpticalThresholdConfig // Thresholds classifying the optical power of the ONUs
+	OID      *config.OltOIDConfig          // OIDs, rack and shelf of the OLT, the ones shared by all OLTs when nil
+	Topology TopologyRepositoryInterface   // Discovered GPON boards and PONs of the OLT
+}
+
+// oltRepository is a struct that implements OltRepositoryInterface
+type oltRepository struct {
+	order       []string                  // OLT IDs in registration order
+	connections map[string]*OltConnection // OLT connections by OLT ID
+}
+
+// NewOltRepository is a constructor function to create a new, empty OLT registry
+func NewOltRepository() OltRepositoryInterface {
+	return &oltRepository{
+		connections: make(map[string]*OltConnection),
+	}
+}
+
+// Register adds an OLT to the registry, replacing any OLT with the same ID.
+// It is meant to be called during startup, before the registry is shared between goroutines.
+func (r *oltRepository) Register(olt model.Olt, conn OltConnection) {
+	if _, ok := r.connections[olt.ID]; !ok {
+		r.order = append(r.order, olt.ID)
+	}
+
+	conn.Olt = olt
+	if conn.Topology == nil {
+		conn.Topology = NewTopologyRepository()
+	}
+	r.connections[olt.ID] = &conn
+}
+
+// List returns all registered OLTs in registration order
+func (r *oltRepository) List() []model.Olt {
+	olts := make([]model.Olt, 0, len(r.order))
+	for _, id := range r.order {
+		olts = append(olts, r.connections[id].Olt)
+	}
+	return olts
+}
+
+// Get returns the connection of the given OLT, or the first registered OLT if oltID is empty
+func (r *oltRepository) Get(oltID string) (*OltConnection, bool) {
+	if oltID == "" {
+		if len(r.order) == 0 {
+			return nil, false
+		}
+		oltID = r.order[0]
+	}
+
+	conn, ok := r.connections[oltID]
+	return conn, ok
+}
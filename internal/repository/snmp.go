@@ -1,78 +1,155 @@
 package repository
 
 import (
+	"context"
 	"fmt"
-	"time"
 
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
 	"github.com/gosnmp/gosnmp"
 )
 
 // SnmpRepositoryInterface is an interface that represents the SNMP repository contract
 type SnmpRepositoryInterface interface {
-	Get(oids []string) (result *gosnmp.SnmpPacket, err error)       // Get SNMP data for the given OIDs
-	Walk(oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error // Walk SNMP to get all OIDs under the given OID
+	Get(ctx context.Context, oids []string) (result *gosnmp.SnmpPacket, err error)           // Get SNMP data for the given OIDs
+	GetBatch(ctx context.Context, oids []string) ([]gosnmp.SnmpPDU, error)                   // Get SNMP data for any number of OIDs, split by max varbinds
+	Walk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error     // Walk SNMP to get all OIDs under the given OID
+	BulkWalk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error // Walk SNMP with GetBulk requests
 }
 
 // snmpRepository is a struct that implements SnmpRepositoryInterface
 type snmpRepository struct {
-	target    string // SNMP target IP address
-	community string // SNMP community string
-	port      uint16 // SNMP port number
+	pool *snmp.Pool // Pool of connected SNMP sessions to the target
 }
 
 // NewPonRepository is a constructor function to create a new instance of snmpRepository
-func NewPonRepository(target string, community string, port uint16) SnmpRepositoryInterface {
+func NewPonRepository(pool *snmp.Pool) SnmpRepositoryInterface {
 	return &snmpRepository{
-		target:    target,    // SNMP target IP address
-		community: community, // SNMP community string
-		port:      port,      // SNMP port number
+		pool: pool, // Pool of connected SNMP sessions to the target
 	}
 }
 
-// buildSNMPInstance for creating a new SNMP instance
-func (r *snmpRepository) buildSNMPInstance() (*gosnmp.GoSNMP, error) {
-	params := &gosnmp.GoSNMP{
-		Target:    r.target,                       // SNMP target IP address
-		Port:      r.port,                         // SNMP port number
-		Community: r.community,                    // SNMP community string
-		Version:   gosnmp.Version2c,               // SNMP version
-		Timeout:   time.Duration(3) * time.Second, // SNMP timeout
-		Retries:   1,                              // Number of retries for SNMP requests
-	}
+// Get to get SNMP data for the given OIDs, the request is aborted when ctx is done
+func (r *snmpRepository) Get(ctx context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	var result *gosnmp.SnmpPacket
 
-	// Set logger to nil to disable logging
-	if err := params.Connect(); err != nil {
-		return nil, fmt.Errorf("SNMP Connect error: %w", err) // Error connecting to SNMP target
+	err := r.pool.Do(ctx, func(snmp *gosnmp.GoSNMP) error {
+		var err error
+		result, err = snmp.Get(oids)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SNMP Get failed: %w", err)
 	}
-	return params, nil // Return the SNMP instance
+	return result, nil
 }
 
-// Get to get SNMP data for the given OIDs
-func (r *snmpRepository) Get(oids []string) (*gosnmp.SnmpPacket, error) {
-	snmp, err := r.buildSNMPInstance() // Create a new SNMP instance
+// GetBatch to get SNMP data for any number of OIDs, split into as few requests as the agent's max varbinds allows.
+// The varbinds are returned in the order of the given OIDs.
+func (r *snmpRepository) GetBatch(ctx context.Context, oids []string) ([]gosnmp.SnmpPDU, error) {
+	variables := make([]gosnmp.SnmpPDU, 0, len(oids))
+
+	err := r.pool.Do(ctx, func(snmp *gosnmp.GoSNMP) error {
+		for _, chunk := range chunkOIDs(oids, snmp.MaxOids) {
+			chunkVariables, err := getChunk(ctx, snmp, chunk)
+			if err != nil {
+				return err
+			}
+			variables = append(variables, chunkVariables...)
+		}
+		return nil
+	})
 	if err != nil {
+		return nil, fmt.Errorf("SNMP GetBatch failed: %w", err)
+	}
+	return variables, nil
+}
+
+// getChunk gets one chunk of OIDs, a chunk the agent reports as too big is split in halves and requested again
+func getChunk(ctx context.Context, snmp *gosnmp.GoSNMP, oids []string) ([]gosnmp.SnmpPDU, error) {
+	// Stop between requests when ctx is done
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
-	defer snmp.Conn.Close()
 
 	result, err := snmp.Get(oids)
 	if err != nil {
-		return nil, fmt.Errorf("SNMP Get failed: %w", err)
+		return nil, err
 	}
-	return result, nil
+
+	if result.Error == gosnmp.TooBig && len(oids) > 1 {
+		half := len(oids) / 2
+		first, err := getChunk(ctx, snmp, oids[:half])
+		if err != nil {
+			return nil, err
+		}
+		second, err := getChunk(ctx, snmp, oids[half:])
+		if err != nil {
+			return nil, err
+		}
+		return append(first, second...), nil
+	}
+
+	if err := errorStatus(result, oids); err != nil {
+		return nil, err
+	}
+	return result.Variables, nil
 }
 
-// Walk for SNMP Walk to get all OIDs under the given OID
-func (r *snmpRepository) Walk(oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
-	snmp, err := r.buildSNMPInstance()
-	if err != nil {
-		return err
+// errorStatus returns the error of a response with an error status, with the OID at the error index when it is set
+func errorStatus(result *gosnmp.SnmpPacket, oids []string) error {
+	if result.Error == gosnmp.NoError {
+		return nil
 	}
-	defer snmp.Conn.Close()
 
-	err = snmp.Walk(oid, walkFunc)
+	if index := int(result.ErrorIndex); index >= 1 && index <= len(oids) {
+		return fmt.Errorf("SNMP error status %s for OID %s", result.Error, oids[index-1])
+	}
+	return fmt.Errorf("SNMP error status %s", result.Error)
+}
+
+// Walk for SNMP Walk to get all OIDs under the given OID, the walk is aborted between PDUs when ctx is done
+func (r *snmpRepository) Walk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	err := r.pool.Do(ctx, func(snmp *gosnmp.GoSNMP) error {
+		return snmp.Walk(oid, walkWithContext(ctx, walkFunc))
+	})
 	if err != nil {
 		return fmt.Errorf("SNMP Walk failed: %w", err)
 	}
 	return nil
 }
+
+// BulkWalk for SNMP Walk with GetBulk requests, fetching many OIDs under the given OID per round trip.
+// The walk is aborted between PDUs when ctx is done.
+func (r *snmpRepository) BulkWalk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	err := r.pool.Do(ctx, func(snmp *gosnmp.GoSNMP) error {
+		return snmp.BulkWalk(oid, walkWithContext(ctx, walkFunc))
+	})
+	if err != nil {
+		return fmt.Errorf("SNMP BulkWalk failed: %w", err)
+	}
+	return nil
+}
+
+// chunkOIDs splits the OIDs into chunks of at most size OIDs
+func chunkOIDs(oids []string, size int) [][]string {
+	if size <= 0 {
+		size = gosnmp.MaxOids
+	}
+
+	var chunks [][]string
+	for start := 0; start < len(oids); start += size {
+		end := min(start+size, len(oids))
+		chunks = append(chunks, oids[start:end])
+	}
+	return chunks
+}
+
+// walkWithContext wraps a walk function to stop the walk as soon as ctx is done
+func walkWithContext(ctx context.Context, walkFunc func(pdu gosnmp.SnmpPDU) error) gosnmp.WalkFunc {
+	return func(pdu gosnmp.SnmpPDU) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return walkFunc(pdu)
+	}
+}
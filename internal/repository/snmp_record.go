@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/achyar10/snmp-olt-zte/pkg/snmpsim"
+	"github.com/gosnmp/gosnmp"
+)
+
+// recordingRepository is a struct that implements SnmpRepositoryInterface, capturing every varbind it returns
+type recordingRepository struct {
+	repo     SnmpRepositoryInterface // Repository the requests are sent through
+	recorder *snmpsim.Recorder       // Recorder the returned varbinds are written to
+}
+
+// NewRecordingRepository is a constructor function to wrap an SNMP repository with a recorder.
+// The recorded snmprec fixture can be served again with NewReplayRepository or the SNMP simulator.
+func NewRecordingRepository(repo SnmpRepositoryInterface, recorder *snmpsim.Recorder) SnmpRepositoryInterface {
+	return &recordingRepository{
+		repo:     repo,
+		recorder: recorder,
+	}
+}
+
+// Get to get SNMP data for the given OIDs and record the returned varbinds
+func (r *recordingRepository) Get(ctx context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	result, err := r.repo.Get(ctx, oids)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.record(result.Variables...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBatch to get SNMP data for any number of OIDs and record the returned varbinds
+func (r *recordingRepository) GetBatch(ctx context.Context, oids []string) ([]gosnmp.SnmpPDU, error) {
+	variables, err := r.repo.GetBatch(ctx, oids)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.record(variables...); err != nil {
+		return nil, err
+	}
+	return variables, nil
+}
+
+// Walk for SNMP Walk to get all OIDs under the given OID, every PDU is recorded before it is passed on
+func (r *recordingRepository) Walk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	return r.repo.Walk(ctx, oid, r.recordWalk(walkFunc))
+}
+
+// BulkWalk for SNMP Walk with GetBulk requests, every PDU is recorded before it is passed on
+func (r *recordingRepository) BulkWalk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	return r.repo.BulkWalk(ctx, oid, r.recordWalk(walkFunc))
+}
+
+// record writes the varbinds to the recorder
+func (r *recordingRepository) record(pdus ...gosnmp.SnmpPDU) error {
+	if err := r.recorder.Record(pdus...); err != nil {
+		return fmt.Errorf("SNMP record failed: %w", err)
+	}
+	return nil
+}
+
+// recordWalk wraps a walk function to record every PDU before it is passed on
+func (r *recordingRepository) recordWalk(walkFunc func(pdu gosnmp.SnmpPDU) error) func(pdu gosnmp.SnmpPDU) error {
+	return func(pdu gosnmp.SnmpPDU) error {
+		if err := r.record(pdu); err != nil {
+			return err
+		}
+		return walkFunc(pdu)
+	}
+}
+
+// replayRepository is a struct that implements SnmpRepositoryInterface, serving a recorded walk instead of an agent
+type replayRepository struct {
+	store *snmpsim.Store // Recorded OIDs of the OLT
+}
+
+// NewReplayRepository is a constructor function to create an SNMP repository serving a recorded walk.
+// Missing OIDs are answered like an agent would, with noSuchObject or noSuchInstance varbinds.
+func NewReplayRepository(store *snmpsim.Store) SnmpRepositoryInterface {
+	return &replayRepository{
+		store: store,
+	}
+}
+
+// Get to get the recorded varbinds of the given OIDs
+func (r *replayRepository) Get(ctx context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	variables, err := r.GetBatch(ctx, oids)
+	if err != nil {
+		return nil, err
+	}
+	return &gosnmp.SnmpPacket{
+		Version:   gosnmp.Version2c,
+		PDUType:   gosnmp.GetResponse,
+		Variables: variables,
+	}, nil
+}
+
+// GetBatch to get the recorded varbinds of any number of OIDs, in the order of the given OIDs
+func (r *replayRepository) GetBatch(ctx context.Context, oids []string) ([]gosnmp.SnmpPDU, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("SNMP GetBatch failed: %w", err)
+	}
+
+	variables := make([]gosnmp.SnmpPDU, len(oids))
+	for i, oid := range oids {
+		variables[i] = r.store.Lookup(oid)
+	}
+	return variables, nil
+}
+
+// Walk to get all recorded OIDs under the given OID, the walk is aborted between PDUs when ctx is done
+func (r *replayRepository) Walk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	if err := r.walk(oid, walkWithContext(ctx, walkFunc)); err != nil {
+		return fmt.Errorf("SNMP Walk failed: %w", err)
+	}
+	return nil
+}
+
+// BulkWalk to get all recorded OIDs under the given OID, a replayed walk needs no GetBulk requests
+func (r *replayRepository) BulkWalk(ctx context.Context, oid string, walkFunc func(pdu gosnmp.SnmpPDU) error) error {
+	if err := r.walk(oid, walkWithContext(ctx, walkFunc)); err != nil {
+		return fmt.Errorf("SNMP BulkWalk failed: %w", err)
+	}
+	return nil
+}
+
+// walk passes the records under the given OID to walkFunc.
+// As with gosnmp, a walk of an OID without children returns the OID itself if it is recorded.
+func (r *replayRepository) walk(oid string, walkFunc gosnmp.WalkFunc) error {
+	root := "." + strings.Trim(oid, ".")
+
+	found := false
+	for pdu := r.store.LookupNext(root); pdu.Type != gosnmp.EndOfMibView; pdu = r.store.LookupNext(pdu.Name) {
+		if !strings.HasPrefix(pdu.Name, root+".") {
+			break
+		}
+		found = true
+		if err := walkFunc(pdu); err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		if record, ok := r.store.Get(root); ok {
+			return walkFunc(record.PDU())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmpsim"
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+)
+
+// walkNames returns the names of the ONUs under the name column
+func walkNames(t *testing.T, repo SnmpRepositoryInterface) []string {
+	var names []string
+	err := repo.BulkWalk(context.Background(), nameColumnOID, func(pdu gosnmp.SnmpPDU) error {
+		names = append(names, string(pdu.Value.([]byte)))
+		return nil
+	})
+	assert.NoError(t, err)
+	return names
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	oids := []string{snmp.SysUpTimeOID, nameColumnOID + ".1", nameColumnOID + ".2"}
+
+	// Record a walk and a Get from the simulated agent
+	var fixture bytes.Buffer
+	recording := NewRecordingRepository(newSimulatorRepository(t, 0), snmpsim.NewRecorder(&fixture))
+	recordedNames := walkNames(t, recording)
+	recorded, err := recording.Get(ctx, oids)
+	assert.NoError(t, err)
+
+	// The noSuchInstance varbind is not recorded, a repeated varbind is recorded once
+	assert.Equal(t, 3, bytes.Count(fixture.Bytes(), []byte("\n")))
+
+	// Replay returns the same varbinds
+	store, err := snmpsim.Load(&fixture)
+	assert.NoError(t, err)
+	replay := NewReplayRepository(store)
+	assert.Equal(t, recordedNames, walkNames(t, replay))
+
+	replayed, err := replay.Get(ctx, oids)
+	assert.NoError(t, err)
+	assert.Equal(t, recorded.Variables, replayed.Variables)
+
+	// A walk of a single recorded OID returns the OID itself
+	var names []string
+	err = replay.Walk(ctx, snmp.SysUpTimeOID, func(pdu gosnmp.SnmpPDU) error {
+		names = append(names, pdu.Name)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{snmp.SysUpTimeOID}, names)
+}
+
+func TestReplayWalkCanceled(t *testing.T) {
+	store, err := snmpsim.LoadFile("../../pkg/snmpsim/testdata/zte-c320.walk")
+	assert.NoError(t, err)
+	repo := NewReplayRepository(store)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	count := 0
+	err = repo.Walk(ctx, ".1.3.6.1.4.1.3902.1082", func(gosnmp.SnmpPDU) error {
+		count++
+		cancel()
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmpsim"
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+)
+
+// nameColumnOID is the ONU name column of board 2 PON 16 in the C320 walk fixture
+const nameColumnOID = ".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.2.285278736"
+
+// newSimulatorRepository starts a simulated C320 agent and returns a repository connected to it
+func newSimulatorRepository(t *testing.T, maxOids int) SnmpRepositoryInterface {
+	store, err := snmpsim.LoadFile("../../pkg/snmpsim/testdata/zte-c320.walk")
+	assert.NoError(t, err)
+
+	server, err := snmpsim.Start("127.0.0.1:0", store, "public")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = server.Close() })
+
+	pool := snmp.NewPool(config.SnmpConfig{
+		Ip:        "127.0.0.1",
+		Port:      uint16(server.Addr().(*net.UDPAddr).Port),
+		Community: "public",
+		MaxOids:   maxOids,
+	})
+	t.Cleanup(pool.Close)
+
+	return NewPonRepository(pool)
+}
+
+func TestChunkOIDs(t *testing.T) {
+	oids := []string{".1.1", ".1.2", ".1.3", ".1.4", ".1.5"}
+
+	testCases := []struct {
+		name     string
+		size     int
+		expected [][]string
+	}{
+		{"one chunk", 60, [][]string{oids}},
+		{"exact split", 5, [][]string{oids}},
+		{"uneven split", 2, [][]string{{".1.1", ".1.2"}, {".1.3", ".1.4"}, {".1.5"}}},
+		{"default size", 0, [][]string{oids}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, chunkOIDs(oids, tc.size))
+		})
+	}
+}
+
+func TestWalkWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var names []string
+	walkFunc := walkWithContext(ctx, func(pdu gosnmp.SnmpPDU) error {
+		names = append(names, pdu.Name)
+		return nil
+	})
+
+	assert.NoError(t, walkFunc(gosnmp.SnmpPDU{Name: ".1.1"}))
+	cancel()
+	assert.ErrorIs(t, walkFunc(gosnmp.SnmpPDU{Name: ".1.2"}), context.Canceled)
+	assert.Equal(t, []string{".1.1"}, names)
+}
+
+func TestSnmpRepositoryWithSimulator(t *testing.T) {
+	repo := newSimulatorRepository(t, 2)
+	ctx := context.Background()
+
+	// BulkWalk and Walk return the same ONU names
+	var bulkNames, walkNames []string
+	err := repo.BulkWalk(ctx, nameColumnOID, func(pdu gosnmp.SnmpPDU) error {
+		bulkNames = append(bulkNames, string(pdu.Value.([]byte)))
+		return nil
+	})
+	assert.NoError(t, err)
+	err = repo.Walk(ctx, nameColumnOID, func(pdu gosnmp.SnmpPDU) error {
+		walkNames = append(walkNames, string(pdu.Value.([]byte)))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ONU-2-16-1", "ONU-2-16-3"}, bulkNames)
+	assert.Equal(t, bulkNames, walkNames)
+
+	// GetBatch splits the OIDs by max varbinds and keeps their order
+	oids := []string{nameColumnOID + ".3", snmp.SysUpTimeOID, nameColumnOID + ".1", nameColumnOID + ".2", ".1.3.6.1.2.1.1.5.0"}
+	variables, err := repo.GetBatch(ctx, oids)
+	assert.NoError(t, err)
+	assert.Len(t, variables, len(oids))
+	for i, variable := range variables {
+		assert.Equal(t, oids[i], variable.Name)
+	}
+	assert.Equal(t, []byte("ONU-2-16-3"), variables[0].Value)
+	assert.Equal(t, gosnmp.NoSuchInstance, variables[3].Type)
+}
+
+func TestSnmpRepositoryWalkCanceled(t *testing.T) {
+	repo := newSimulatorRepository(t, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	count := 0
+	err := repo.BulkWalk(ctx, ".1.3.6.1.4.1.3902.1082", func(gosnmp.SnmpPDU) error {
+		count++
+		cancel() // The client went away after the first PDU
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, count)
+}
+
+func TestSnmpRepositoryGetBatchTooBig(t *testing.T) {
+	store, err := snmpsim.LoadFile("../../pkg/snmpsim/testdata/zte-c320.walk")
+	assert.NoError(t, err)
+
+	// An agent answering more than 2 varbinds with tooBig
+	server := snmpsim.NewServer(store, "public")
+	server.MaxVarbinds = 2
+	assert.NoError(t, server.Listen("127.0.0.1:0"))
+	go func() { _ = server.Serve() }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	pool := snmp.NewPool(config.SnmpConfig{
+		Ip: "127.0.0.1", Port: uint16(server.Addr().(*net.UDPAddr).Port), Community: "public",
+	})
+	t.Cleanup(pool.Close)
+	repo := NewPonRepository(pool)
+
+	// The chunk is split until the agent answers, the varbinds keep the order of the OIDs
+	oids := []string{nameColumnOID + ".3", snmp.SysUpTimeOID, nameColumnOID + ".1", nameColumnOID + ".2", ".1.3.6.1.2.1.1.5.0"}
+	variables, err := repo.GetBatch(context.Background(), oids)
+	assert.NoError(t, err)
+	assert.Len(t, variables, len(oids))
+	for i, variable := range variables {
+		assert.Equal(t, oids[i], variable.Name)
+	}
+	assert.Equal(t, []byte("ONU-2-16-1"), variables[2].Value)
+}
+
+func TestErrorStatus(t *testing.T) {
+	oids := []string{".1.1", ".1.2"}
+
+	testCases := []struct {
+		name   string
+		result *gosnmp.SnmpPacket
+		err    string
+	}{
+		{"no error", &gosnmp.SnmpPacket{Error: gosnmp.NoError}, ""},
+		{"error index", &gosnmp.SnmpPacket{Error: gosnmp.GenErr, ErrorIndex: 2}, "SNMP error status GenErr for OID .1.2"},
+		{"without error index", &gosnmp.SnmpPacket{Error: gosnmp.TooBig}, "SNMP error status TooBig"},
+		{"error index out of range", &gosnmp.SnmpPacket{Error: gosnmp.NoSuchName, ErrorIndex: 3}, "SNMP error status NoSuchName"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := errorStatus(tc.result, oids)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
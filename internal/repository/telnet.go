@@ -1,19 +1,45 @@
-package utils
+package repository
 
 import (
 	"bufio"
 	"fmt"
 	"log"
 	"net"
-	"os"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/achyar10/snmp-olt-zte/config"
 	"golang.org/x/text/encoding/simplifiedchinese"
 	"golang.org/x/text/transform"
 )
 
+// TelnetRepositoryInterface is an interface that represents the telnet (CLI) repository contract
+type TelnetRepositoryInterface interface {
+	Run(command string) (string, error) // Run a CLI command on the OLT and return its output
+}
+
+// telnetRepository is a struct that implements TelnetRepositoryInterface
+type telnetRepository struct {
+	ip       string // Telnet IP address
+	port     uint16 // Telnet port number
+	username string // Telnet username
+	password string // Telnet password
+}
+
+// NewTelnetRepository is a constructor function to create a new instance of telnetRepository
+func NewTelnetRepository(ip string, port uint16, username, password string) TelnetRepositoryInterface {
+	if port == 0 {
+		port = 23 // Default telnet port
+	}
+
+	return &telnetRepository{
+		ip:       ip,
+		port:     port,
+		username: username,
+		password: password,
+	}
+}
+
 func decodeGBK(input string) string {
 	reader := transform.NewReader(strings.NewReader(input), simplifiedchinese.GBK.NewDecoder())
 	decoded, err := bufio.NewReader(reader).ReadString('\n')
@@ -57,14 +83,9 @@ func readAndLogRaw(conn net.Conn, label string, maxTime time.Duration) {
 	log.Println("📡 Raw output end")
 }
 
-func RunTelnetCommand(command string) (string, error) {
-	configPath := GetConfigPath(os.Getenv("APP_ENV"))
-	cfg, err := config.LoadConfig(configPath)
-	if err != nil {
-		log.Fatalf("Error loading config: %v", err)
-	}
-
-	address := fmt.Sprintf("%s:23", cfg.TelnetCfg.Ip)
+// Run logs in to the OLT over telnet, runs the command and returns its output
+func (r *telnetRepository) Run(command string) (string, error) {
+	address := net.JoinHostPort(r.ip, strconv.Itoa(int(r.port)))
 	conn, err := net.DialTimeout("tcp", address, 5*time.Second)
 	if err != nil {
 		return "", fmt.Errorf("failed to connect: %w", err)
@@ -77,11 +98,11 @@ func RunTelnetCommand(command string) (string, error) {
 
 	readAndLogRaw(conn, "initial-banner", 5*time.Second)
 
-	writer.WriteString(cfg.TelnetCfg.Username + "\n")
+	writer.WriteString(r.username + "\n")
 	writer.Flush()
 	time.Sleep(1 * time.Second)
 
-	writer.WriteString(cfg.TelnetCfg.Password + "\n")
+	writer.WriteString(r.password + "\n")
 	writer.Flush()
 	time.Sleep(1 * time.Second)
 
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"sync"
+
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+)
+
+// TopologyRepositoryInterface is an interface that represents the store of the discovered topology of an OLT
+type TopologyRepositoryInterface interface {
+	Get() (model.OltTopology, bool)  // Get the discovered topology, false until it is discovered
+	Save(topology model.OltTopology) // Save the discovered topology, replacing the previous one
+}
+
+// topologyRepository is a struct that implements TopologyRepositoryInterface, shared between the requests and the
+// periodic discovery
+type topologyRepository struct {
+	mu         sync.RWMutex
+	topology   model.OltTopology
+	discovered bool
+}
+
+// NewTopologyRepository is a constructor function to create a new topology store, empty until a topology is saved
+func NewTopologyRepository() TopologyRepositoryInterface {
+	return &topologyRepository{}
+}
+
+// Get returns the last saved topology
+func (r *topologyRepository) Get() (model.OltTopology, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.topology, r.discovered
+}
+
+// Save stores the topology, it must not be modified afterwards
+func (r *topologyRepository) Save(topology model.OltTopology) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.topology, r.discovered = topology, true
+}
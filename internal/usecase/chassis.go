@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/internal/utils"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/gosnmp/gosnmp"
+	"github.com/rs/zerolog/log"
+)
+
+// chassisColumn is a column of a chassis table with the setter of its field, the index is the index of the table row
+type chassisColumn struct {
+	oid string
+	set func(index []int, value interface{})
+}
+
+func (u *oltUsecase) GetOltChassis(ctx context.Context, oltID string) (model.OltChassis, error) {
+	// Get OLT connection
+	olt, ok := u.oltRepository.Get(oltID)
+	if !ok {
+		log.Error().Msg("OLT not found: " + oltID)
+		return model.OltChassis{}, ErrOltNotFound
+	}
+
+	// Set key for simple flight
+	key := "olt_chassis:" + olt.Olt.ID
+
+	// Using simple flight to prevent duplicate SNMP requests
+	result, err := doShared(ctx, &u.sg, key, func(ctx context.Context) (interface{}, error) {
+		log.Info().Msg("Get OLT chassis with SNMP from OLT: " + olt.Olt.ID)
+
+		chassis := model.OltChassis{
+			OltID:              olt.Olt.ID,
+			Cards:              make([]model.OltCard, 0),
+			Fans:               make([]model.OltFan, 0),
+			TemperatureSensors: make([]model.OltTemperatureSensor, 0),
+			PowerSupplies:      make([]model.OltPowerSupply, 0),
+		}
+
+		// System group scalars
+		variables, err := olt.Snmp.GetBatch(ctx, []string{snmp.SysDescrOID, snmp.SysNameOID, snmp.SysUpTimeOID})
+		if err != nil {
+			log.Error().Msg("Failed to perform SNMP Get for system group: " + err.Error())
+			return model.OltChassis{}, fmt.Errorf("failed to perform SNMP Get: %w", err)
+		}
+		for _, pdu := range variables {
+			if !hasValue(pdu) {
+				continue
+			}
+			switch "." + strings.TrimPrefix(pdu.Name, ".") {
+			case snmp.SysDescrOID:
+				chassis.SysDescr = utils.ExtractName(pdu.Value)
+			case snmp.SysNameOID:
+				chassis.SysName = utils.ExtractName(pdu.Value)
+			case snmp.SysUpTimeOID:
+				// TimeTicks are hundredths of a second
+				if ticks, ok := utils.ExtractCounter(pdu.Value); ok {
+					seconds := int64(ticks / 100)
+					chassis.SysUptimeSeconds = &seconds
+					chassis.SysUptime = utils.ConvertDurationToString(time.Duration(seconds) * time.Second)
+				}
+			}
+		}
+
+		// Chassis table rows joined by index
+		cards := make(map[[3]int]*model.OltCard)
+		fans := make(map[int]*model.OltFan)
+		sensors := make(map[int]*model.OltTemperatureSensor)
+		psus := make(map[int]*model.OltPowerSupply)
+
+		for _, column := range u.chassisColumns(olt, cards, fans, sensors, psus) {
+			err := olt.Snmp.BulkWalk(ctx, column.oid, func(pdu gosnmp.SnmpPDU) error {
+				index, ok := utils.ExtractTableIndex(pdu.Name, column.oid)
+				if !ok || !hasValue(pdu) {
+					return nil
+				}
+				column.set(index, pdu.Value)
+				return nil
+			})
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return model.OltChassis{}, ctxErr
+			}
+			if err != nil {
+				// A chassis without a column is incomplete, like a PON listing without a column
+				log.Error().Msg("Failed to perform SNMP BulkWalk for OID " + column.oid + ": " + err.Error())
+				return model.OltChassis{}, fmt.Errorf("failed to perform SNMP BulkWalk: %w", err)
+			}
+		}
+
+		for _, card := range cards {
+			chassis.Cards = append(chassis.Cards, *card)
+		}
+		sort.Slice(chassis.Cards, func(i, j int) bool {
+			a, b := chassis.Cards[i], chassis.Cards[j]
+			if a.Rack != b.Rack {
+				return a.Rack < b.Rack
+			}
+			if a.Shelf != b.Shelf {
+				return a.Shelf < b.Shelf
+			}
+			return a.Slot < b.Slot
+		})
+
+		for _, fan := range fans {
+			chassis.Fans = append(chassis.Fans, *fan)
+		}
+		sort.Slice(chassis.Fans, func(i, j int) bool {
+			return chassis.Fans[i].Fan < chassis.Fans[j].Fan
+		})
+
+		for _, sensor := range sensors {
+			chassis.TemperatureSensors = append(chassis.TemperatureSensors, *sensor)
+		}
+		sort.Slice(chassis.TemperatureSensors, func(i, j int) bool {
+			return chassis.TemperatureSensors[i].Sensor < chassis.TemperatureSensors[j].Sensor
+		})
+
+		for _, psu := range psus {
+			chassis.PowerSupplies = append(chassis.PowerSupplies, *psu)
+		}
+		sort.Slice(chassis.PowerSupplies, func(i, j int) bool {
+			return chassis.PowerSupplies[i].PSU < chassis.PowerSupplies[j].PSU
+		})
+
+		return chassis, nil
+	})
+
+	if err != nil {
+		log.Error().Msg("Failed to get OLT chassis: " + err.Error())
+		return model.OltChassis{}, err
+	}
+
+	return result.(model.OltChassis), nil
+}
+
+// chassisColumns returns the configured columns of the chassis tables, setting the rows of the given maps
+func (u *oltUsecase) chassisColumns(
+	olt *repository.OltConnection, cards map[[3]int]*model.OltCard, fans map[int]*model.OltFan, sensors map[int]*model.OltTemperatureSensor,
+	psus map[int]*model.OltPowerSupply,
+) []chassisColumn {
+	oltOID := getOltOID(u.cfg, olt)
+	baseOID2 := oltOID.BaseOID2
+	chassisOID := oltOID.ChassisOID
+
+	// oid returns the OID of a chassis column, empty when the column is not configured
+	oid := func(columnOID string) string {
+		if columnOID == "" {
+			return ""
+		}
+		return baseOID2 + columnOID
+	}
+
+	// Cards are indexed by rack.shelf.slot
+	card := func(set func(value interface{}, card *model.OltCard)) func(index []int, value interface{}) {
+		return func(index []int, value interface{}) {
+			if len(index) != 3 {
+				return
+			}
+			key := [3]int{index[0], index[1], index[2]}
+			row, ok := cards[key]
+			if !ok {
+				row = &model.OltCard{Rack: index[0], Shelf: index[1], Slot: index[2], Status: "unknown"}
+				cards[key] = row
+			}
+			set(value, row)
+		}
+	}
+
+	// Fans are indexed by rack.shelf.fan
+	fan := func(set func(value interface{}, fan *model.OltFan)) func(index []int, value interface{}) {
+		return func(index []int, value interface{}) {
+			if len(index) != 3 {
+				return
+			}
+			row, ok := fans[index[2]]
+			if !ok {
+				row = &model.OltFan{Fan: index[2], Status: "unknown"}
+				fans[index[2]] = row
+			}
+			set(value, row)
+		}
+	}
+
+	// Temperature sensors are indexed by sensor
+	sensor := func(set func(value interface{}, sensor *model.OltTemperatureSensor)) func(index []int, value interface{}) {
+		return func(index []int, value interface{}) {
+			if len(index) != 1 {
+				return
+			}
+			row, ok := sensors[index[0]]
+			if !ok {
+				row = &model.OltTemperatureSensor{Sensor: index[0]}
+				sensors[index[0]] = row
+			}
+			set(value, row)
+		}
+	}
+
+	columns := []chassisColumn{
+		{oid(chassisOID.CardTypeOID), card(func(value interface{}, card *model.OltCard) {
+			card.Type = utils.ExtractName(value)
+		})},
+		{oid(chassisOID.CardStatusOID), card(func(value interface{}, card *model.OltCard) {
+			card.Status = utils.ExtractCardStatus(value)
+		})},
+		{oid(chassisOID.CardSoftwareVersionOID), card(func(value interface{}, card *model.OltCard) {
+			card.SoftwareVersion = utils.ExtractName(value)
+		})},
+		{oid(chassisOID.CardCpuUsageOID), card(func(value interface{}, card *model.OltCard) {
+			if usage, ok := utils.ExtractInteger(value); ok {
+				card.CpuUsagePercent = &usage
+			}
+		})},
+		{oid(chassisOID.CardMemoryUsageOID), card(func(value interface{}, card *model.OltCard) {
+			if usage, ok := utils.ExtractInteger(value); ok {
+				card.MemoryUsagePercent = &usage
+			}
+		})},
+		{oid(chassisOID.FanStatusOID), fan(func(value interface{}, fan *model.OltFan) {
+			fan.Status = utils.ExtractDeviceStatus(value)
+		})},
+		{oid(chassisOID.FanSpeedOID), fan(func(value interface{}, fan *model.OltFan) {
+			if speed, ok := utils.ExtractInteger(value); ok {
+				fan.SpeedPercent = &speed
+			}
+		})},
+		{oid(chassisOID.TemperatureNameOID), sensor(func(value interface{}, sensor *model.OltTemperatureSensor) {
+			sensor.Name = utils.ExtractName(value)
+		})},
+		{oid(chassisOID.TemperatureOID), sensor(func(value interface{}, sensor *model.OltTemperatureSensor) {
+			if temperature, ok := utils.ExtractInteger(value); ok {
+				sensor.Temperature = &temperature
+			}
+		})},
+		// Power supplies are indexed by rack.shelf.psu
+		{oid(chassisOID.PowerStatusOID), func(index []int, value interface{}) {
+			if len(index) != 3 {
+				return
+			}
+			psus[index[2]] = &model.OltPowerSupply{PSU: index[2], Status: utils.ExtractDeviceStatus(value)}
+		}},
+	}
+
+	// Skip the columns that are not configured, their tables are left empty
+	configured := columns[:0]
+	for _, column := range columns {
+		if column.oid != "" {
+			configured = append(configured, column)
+		}
+	}
+	return configured
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOltChassisWithReplay(t *testing.T) {
+	testCases := []struct {
+		name        string
+		snmp        repository.SnmpRepositoryInterface
+		configure   func(cfg *config.Config)
+		wantCards   int
+		wantFans    int
+		wantSensors int
+		wantPSUs    int
+		wantErr     error
+	}{
+		{name: "all tables", wantCards: 4, wantFans: 3, wantSensors: 2, wantPSUs: 2},
+		{
+			// Config files without the fan and power tables leave them empty instead of walking the base OID
+			name: "without fan and power OIDs",
+			configure: func(cfg *config.Config) {
+				cfg.OltCfg.ChassisOID.FanStatusOID = ""
+				cfg.OltCfg.ChassisOID.FanSpeedOID = ""
+				cfg.OltCfg.ChassisOID.PowerStatusOID = ""
+			},
+			wantCards: 4, wantSensors: 2,
+		},
+		{
+			// Config files without the chassis tables return the system group only
+			name: "without chassis OIDs",
+			configure: func(cfg *config.Config) {
+				cfg.OltCfg.ChassisOID = config.ChassisOIDCfg{}
+			},
+		},
+		{
+			// A chassis without its fans is not returned as complete
+			name: "failed column",
+			snmp: columnErrorSnmp{
+				SnmpRepositoryInterface: newReplaySnmp(t),
+				prefix:                  ".1.3.6.1.4.1.3902.1012.3.5.1.1.3",
+				err:                     snmp.ErrCircuitOpen,
+			},
+			wantErr: snmp.ErrCircuitOpen,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig(true)
+			if tc.configure != nil {
+				tc.configure(cfg)
+			}
+			uc := NewOltUsecase(newReplayOltRepository(t, repository.OltConnection{Snmp: tc.snmp}), cfg)
+
+			chassis, err := uc.GetOltChassis(context.Background(), "")
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, repository.DefaultOltID, chassis.OltID)
+			assert.Equal(t, "ZXA10 C320, ZTE ZXA10 Software Version: V2.1.0", chassis.SysDescr)
+			assert.Len(t, chassis.Cards, tc.wantCards)
+			assert.Len(t, chassis.Fans, tc.wantFans)
+			assert.Len(t, chassis.TemperatureSensors, tc.wantSensors)
+			assert.Len(t, chassis.PowerSupplies, tc.wantPSUs)
+		})
+	}
+}
+
+func TestGetOltChassisTables(t *testing.T) {
+	uc := NewOltUsecase(newReplayOltRepository(t, repository.OltConnection{}), newTestConfig(true))
+
+	chassis, err := uc.GetOltChassis(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "OLT-C320-LAB", chassis.SysName)
+	assert.Equal(t, int64(2635123), *chassis.SysUptimeSeconds)
+	assert.Equal(t, "30 days 11 hours 58 minutes 43 seconds", chassis.SysUptime)
+
+	// 2 GPON boards and 2 control cards, sorted by slot
+	cpu, memory := 12, 38
+	assert.Equal(t, model.OltCard{
+		Rack: 1, Shelf: 1, Slot: 1, Type: "GTGH", Status: "inService", SoftwareVersion: "V2.1.0",
+		CpuUsagePercent: &cpu, MemoryUsagePercent: &memory,
+	}, chassis.Cards[0])
+	assert.Equal(t, "SMXA", chassis.Cards[3].Type)
+	assert.Equal(t, 4, chassis.Cards[3].Slot)
+
+	assert.Equal(t, "normal", chassis.Fans[0].Status)
+	assert.Equal(t, 45, *chassis.Fans[0].SpeedPercent)
+	assert.Equal(t, "absent", chassis.Fans[2].Status)
+
+	assert.Equal(t, "Outlet", chassis.TemperatureSensors[1].Name)
+	assert.Equal(t, 41, *chassis.TemperatureSensors[1].Temperature)
+
+	assert.Equal(t, []model.OltPowerSupply{{PSU: 1, Status: "normal"}, {PSU: 2, Status: "normal"}}, chassis.PowerSupplies)
+}
+
+func TestGetOltChassisDecoding(t *testing.T) {
+	// The card table of the C320 walk fixture, card 1 is a GTGH in service with a CPU usage of 12%
+	const cardOID = ".1.3.6.1.4.1.3902.1012.3.3.1.1."
+
+	testCases := []struct {
+		name           string
+		snmp           repository.SnmpRepositoryInterface
+		wantUptime     *int64
+		wantCardStatus string
+		wantCpuUsage   *int
+	}{
+		{name: "all columns", wantUptime: ptr(int64(2635123)), wantCardStatus: "inService", wantCpuUsage: ptr(12)},
+		// The cards are still listed without their CPU usage
+		{
+			name:       "missing CPU usage column",
+			snmp:       newPatchedReplaySnmp(t, cardOID+"9."),
+			wantUptime: ptr(int64(2635123)), wantCardStatus: "inService",
+		},
+		{
+			name:       "card status of the wrong type",
+			snmp:       newPatchedReplaySnmp(t, "", cardOID+`5.1.1.1 = STRING: "1"`),
+			wantUptime: ptr(int64(2635123)), wantCardStatus: "unknown", wantCpuUsage: ptr(12),
+		},
+		{
+			name:           "uptime of the wrong type",
+			snmp:           newPatchedReplaySnmp(t, "", `.1.3.6.1.2.1.1.3.0 = STRING: "30 days"`),
+			wantCardStatus: "inService", wantCpuUsage: ptr(12),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := NewOltUsecase(newReplayOltRepository(t, repository.OltConnection{Snmp: tc.snmp}), newTestConfig(true))
+
+			chassis, err := uc.GetOltChassis(context.Background(), "")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantUptime, chassis.SysUptimeSeconds)
+			assert.Len(t, chassis.Cards, 4)
+			assert.Equal(t, "GTGH", chassis.Cards[0].Type)
+			assert.Equal(t, tc.wantCardStatus, chassis.Cards[0].Status)
+			assert.Equal(t, tc.wantCpuUsage, chassis.Cards[0].CpuUsagePercent)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/internal/utils"
+	"github.com/gosnmp/gosnmp"
+	"github.com/rs/zerolog/log"
+)
+
+// onuInventoryColumn is a per-PON inventory column with the setter of its ONUInventory field
+type onuInventoryColumn struct {
+	oid string
+	set func(value interface{}, inventory *model.ONUInventory)
+}
+
+// onuInventoryColumns returns the configured inventory columns of the ONUs of a PON, the ONU ID is appended per ONU
+func (u *onuUsecase) onuInventoryColumns(olt *repository.OltConnection, oltConfig *model.OltConfig) []onuInventoryColumn {
+	baseOID2 := getOltOID(u.cfg, olt).BaseOID2
+
+	// oid returns the OID of an inventory column, empty when the column is not configured
+	oid := func(attributeOID string) string {
+		if attributeOID == "" {
+			return ""
+		}
+		return baseOID2 + attributeOID
+	}
+
+	columns := []onuInventoryColumn{
+		{oid(oltConfig.OnuVendorIDOID), func(value interface{}, inventory *model.ONUInventory) {
+			inventory.VendorID = utils.ExtractName(value)
+		}},
+		{oid(oltConfig.OnuEquipmentIDOID), func(value interface{}, inventory *model.ONUInventory) {
+			inventory.EquipmentID = utils.ExtractName(value)
+		}},
+		{oid(oltConfig.OnuHardwareVersionOID), func(value interface{}, inventory *model.ONUInventory) {
+			inventory.HardwareVersion = utils.ExtractName(value)
+		}},
+		{oid(oltConfig.OnuActiveSwVersionOID), func(value interface{}, inventory *model.ONUInventory) {
+			inventory.ActiveSoftwareVersion = utils.ExtractName(value)
+		}},
+		{oid(oltConfig.OnuStandbySwVersionOID), func(value interface{}, inventory *model.ONUInventory) {
+			inventory.StandbySoftwareVersion = utils.ExtractName(value)
+		}},
+		{oid(oltConfig.OnuMacAddressOID), func(value interface{}, inventory *model.ONUInventory) {
+			inventory.MacAddress = utils.ExtractMacAddress(value)
+		}},
+	}
+
+	// Skip the columns that are not configured, the inventory is then read over telnet
+	configured := columns[:0]
+	for _, column := range columns {
+		if column.oid != "" {
+			configured = append(configured, column)
+		}
+	}
+	return configured
+}
+
+func (u *onuUsecase) GetONUInventory(ctx context.Context, oltID string, boardID, ponID int) ([]model.ONUInventoryItem, error) {
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set key for simple flight
+	key := fmt.Sprintf("onu_inventory:%s:%d:%d", olt.Olt.ID, boardID, ponID)
+
+	// Using simple flight to prevent duplicate SNMP requests and telnet sessions
+	result, err := doShared(ctx, &u.sg, key, func(ctx context.Context) (interface{}, error) {
+		oltConfig, err := u.getOltConfig(olt, boardID, ponID) // Get OLT config based on Board ID and PON ID
+		if err != nil {
+			log.Error().Msg("Failed to get OLT Config: " + err.Error())
+			return nil, err
+		}
+
+		// The registered ONUs of the PON, sorted by ONU ID
+		onuInformationList, err := u.getONUInfoList(ctx, oltID, boardID, ponID)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Info().Msg("Get ONU Inventory with SNMP BulkWalk from Board ID: " + strconv.Itoa(boardID) + " PON ID: " + strconv.Itoa(ponID))
+
+		inventoryList := make([]model.ONUInventoryItem, len(onuInformationList))
+		inventoryMap := make(map[int]*model.ONUInventoryItem, len(onuInformationList))
+		for i, onuInfo := range onuInformationList {
+			inventoryList[i] = model.ONUInventoryItem{
+				Board:        onuInfo.Board,
+				PON:          onuInfo.PON,
+				ID:           onuInfo.ID,
+				Name:         onuInfo.Name,
+				OnuType:      onuInfo.OnuType,
+				SerialNumber: onuInfo.SerialNumber,
+				Status:       onuInfo.Status,
+			}
+			inventoryMap[onuInfo.ID] = &inventoryList[i]
+		}
+
+		// Inventory columns joined to the ONUs by ONU ID
+		for _, column := range u.onuInventoryColumns(olt, oltConfig) {
+			err := olt.Snmp.BulkWalk(ctx, column.oid, func(pdu gosnmp.SnmpPDU) error {
+				onuID, ok := utils.ExtractOnuIndex(pdu.Name, column.oid, "")
+				if !ok {
+					return nil
+				}
+				if item, ok := inventoryMap[onuID]; ok && hasValue(pdu) {
+					column.set(pdu.Value, &item.ONUInventory)
+				}
+				return nil
+			})
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			if err != nil {
+				// An agent that stopped answering is not a missing inventory to read over telnet
+				log.Error().Msg("Failed to perform SNMP BulkWalk for OID " + column.oid + ": " + err.Error())
+				return nil, fmt.Errorf("failed to perform SNMP BulkWalk: %w", err)
+			}
+		}
+
+		missing := 0
+		for i := range inventoryList {
+			if inventoryList[i].ONUInventory == (model.ONUInventory{}) {
+				missing++
+				continue
+			}
+			inventoryList[i].Source = model.SourceSnmp
+		}
+		if missing == 0 {
+			return inventoryList, nil
+		}
+
+		// Fall back to the CLI for the ONUs without inventory in the MIB
+		telnetInventory, err := u.getONUInventoryWithTelnet(ctx, olt, boardID, ponID)
+		if err != nil {
+			log.Error().Msg("Failed to get ONU inventory with telnet: " + err.Error())
+			return inventoryList, nil
+		}
+		for i := range inventoryList {
+			inventory, ok := telnetInventory[inventoryList[i].ID]
+			if inventoryList[i].Source != "" || !ok {
+				continue
+			}
+			inventoryList[i].ONUInventory = inventory
+			inventoryList[i].Source = model.SourceTelnet
+		}
+
+		return inventoryList, nil
+	})
+
+	if err != nil {
+		log.Error().Msg("Failed to get ONU inventory: " + err.Error())
+		return nil, err
+	}
+
+	return result.([]model.ONUInventoryItem), nil
+}
+
+// getONUInventoryWithTelnet is a function to get the inventory of the ONUs of a PON by ONU ID with
+// "show gpon remote-onu equip"
+func (u *onuUsecase) getONUInventoryWithTelnet(ctx context.Context, olt *repository.OltConnection, boardID, ponID int) (
+	map[int]model.ONUInventory, error,
+) {
+	if olt.Telnet == nil {
+		return nil, errors.New("telnet is not configured")
+	}
+
+	// Do not start a telnet session for a canceled request
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	_, shelf := u.getRackShelf(olt)
+	output, err := olt.Telnet.Run("show gpon remote-onu equip " + utils.GponOltName(shelf, boardID, ponID))
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.ParseONUEquipOutput(output), nil
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/stretchr/testify/assert"
+)
+
+// telnetStub is a TelnetRepositoryInterface returning a fixed output for every command
+type telnetStub struct {
+	output   string
+	commands *[]string
+}
+
+func (t telnetStub) Run(command string) (string, error) {
+	*t.commands = append(*t.commands, command)
+	return t.output, nil
+}
+
+func TestGetONUInventoryWithReplay(t *testing.T) {
+	// The MIB has the inventory of ONU 1 and 2 of board 1 PON 1, the inventory of ONU 3 is read over telnet
+	testCases := []struct {
+		name         string
+		snmp         repository.SnmpRepositoryInterface
+		configure    func(cfg *config.Config)
+		ponID        int
+		wantSources  []string
+		wantCommands []string
+		wantErr      error
+	}{
+		{
+			name:         "MIB and telnet",
+			ponID:        1,
+			wantSources:  []string{model.SourceSnmp, model.SourceSnmp, model.SourceTelnet},
+			wantCommands: []string{"show gpon remote-onu equip gpon-olt_1/1/1"},
+		},
+		{
+			// Without inventory columns the base OID is not walked, the inventory is read over telnet
+			name: "without inventory OIDs",
+			configure: func(cfg *config.Config) {
+				cfg.OltCfg.PonOID.OnuVendorIDOID = ""
+				cfg.OltCfg.PonOID.OnuEquipmentIDOID = ""
+				cfg.OltCfg.PonOID.OnuHardwareVersionOID = ""
+				cfg.OltCfg.PonOID.OnuActiveSwVersionOID = ""
+				cfg.OltCfg.PonOID.OnuStandbySwVersionOID = ""
+				cfg.OltCfg.PonOID.OnuMacAddressOID = ""
+			},
+			ponID:        1,
+			wantSources:  []string{"", "", model.SourceTelnet},
+			wantCommands: []string{"show gpon remote-onu equip gpon-olt_1/1/1"},
+		},
+		{name: "nonexistent PON", ponID: 17},
+		{
+			// The inventory of an agent that stopped answering is not read over telnet instead
+			name: "failed column",
+			snmp: columnErrorSnmp{
+				SnmpRepositoryInterface: newReplaySnmp(t),
+				prefix:                  ".1.3.6.1.4.1.3902.1012.3.50.11.2.1.1.",
+				err:                     snmp.ErrCircuitOpen,
+			},
+			ponID: 1, wantErr: snmp.ErrCircuitOpen,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig(true)
+			if tc.configure != nil {
+				tc.configure(cfg)
+			}
+			var commands []string
+			oltRepo := newReplayOltRepository(t, repository.OltConnection{
+				Snmp: tc.snmp,
+				Telnet: telnetStub{
+					output:   "ONU interface: gpon-onu_1/1/1:3\nVendor ID: ZTEG\nEquipmentID: F609\nVersion: V5.0\n",
+					commands: &commands,
+				},
+			})
+			uc := NewOnuUsecase(oltRepo, cacheStub{}, cfg)
+
+			inventory, err := uc.GetONUInventory(context.Background(), "", 1, tc.ponID)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, inventory, len(tc.wantSources))
+			for i, item := range inventory {
+				assert.Equal(t, i+1, item.ID)
+				assert.Equal(t, tc.wantSources[i], item.Source, "ONU %d", item.ID)
+			}
+			assert.Equal(t, tc.wantCommands, commands)
+		})
+	}
+}
+
+func TestGetONUInventoryDecoding(t *testing.T) {
+	// The inventory columns of the ONUs, the rows of board 1 PON 1 ONU 1 are indexed by 268501248.1
+	const inventoryOID = ".1.3.6.1.4.1.3902.1012.3.50.11.2.1."
+
+	testCases := []struct {
+		name         string
+		snmp         repository.SnmpRepositoryInterface
+		wantHardware string
+		wantMac      string
+	}{
+		{name: "all columns", wantHardware: "V6.0", wantMac: "00:1a:2b:3c:4d:01"},
+		// The ONU keeps the inventory of the other columns
+		{name: "missing MAC address column", snmp: newPatchedReplaySnmp(t, inventoryOID+"8."), wantHardware: "V6.0"},
+		{
+			name:         "MAC address of the wrong type",
+			snmp:         newPatchedReplaySnmp(t, "", inventoryOID+"8.268501248.1 = INTEGER: 1"),
+			wantHardware: "V6.0",
+		},
+		{
+			name:         "hardware version of the wrong type",
+			snmp:         newPatchedReplaySnmp(t, "", inventoryOID+"2.268501248.1 = INTEGER: 6"),
+			wantHardware: "Unknown", wantMac: "00:1a:2b:3c:4d:01",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var commands []string
+			oltRepo := newReplayOltRepository(t, repository.OltConnection{
+				Snmp:   tc.snmp,
+				Telnet: telnetStub{commands: &commands},
+			})
+			uc := NewOnuUsecase(oltRepo, cacheStub{}, newTestConfig(true))
+
+			inventory, err := uc.GetONUInventory(context.Background(), "", 1, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, model.SourceSnmp, inventory[0].Source)
+			assert.Equal(t, "F660", inventory[0].EquipmentID)
+			assert.Equal(t, tc.wantHardware, inventory[0].HardwareVersion)
+			assert.Equal(t, tc.wantMac, inventory[0].MacAddress)
+		})
+	}
+}
+
+func TestGetONUInventoryItems(t *testing.T) {
+	var commands []string
+	oltRepo := newReplayOltRepository(t, repository.OltConnection{
+		Telnet: telnetStub{
+			output:   "ONU interface: gpon-onu_1/1/1:3\nVendor ID: ZTEG\nEquipmentID: F609\nVersion: V5.0\n",
+			commands: &commands,
+		},
+	})
+	uc := NewOnuUsecase(oltRepo, cacheStub{}, newTestConfig(true))
+
+	inventory, err := uc.GetONUInventory(context.Background(), "", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ONUInventory{
+		VendorID:               "ZTEG",
+		EquipmentID:            "F660",
+		HardwareVersion:        "V6.0",
+		ActiveSoftwareVersion:  "V6.0.10P2N2",
+		StandbySoftwareVersion: "V6.0.10P2N1",
+		MacAddress:             "00:1a:2b:3c:4d:01",
+	}, inventory[0].ONUInventory)
+	assert.Equal(t, "V5.2.10P3N1", inventory[1].ActiveSoftwareVersion)
+	assert.Equal(t, "F609", inventory[2].EquipmentID)
+	assert.Equal(t, "V5.0", inventory[2].HardwareVersion)
+
+	// The ONU detail has the inventory of the MIB
+	onu, err := uc.GetByBoardIDPonIDAndOnuIDV2(context.Background(), "", 1, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "F609", onu.Inventory.EquipmentID)
+	assert.Equal(t, "00:1a:2b:3c:4d:02", onu.Inventory.MacAddress)
+
+	// Without inventory columns the base OID is not walked
+	cfg := newTestConfig(true)
+	cfg.OltCfg.PonOID.OnuVendorIDOID = ""
+	cfg.OltCfg.PonOID.OnuEquipmentIDOID = ""
+	cfg.OltCfg.PonOID.OnuHardwareVersionOID = ""
+	cfg.OltCfg.PonOID.OnuActiveSwVersionOID = ""
+	cfg.OltCfg.PonOID.OnuStandbySwVersionOID = ""
+	cfg.OltCfg.PonOID.OnuMacAddressOID = ""
+	u := &onuUsecase{cfg: cfg}
+	olt := &repository.OltConnection{}
+	oltConfig, err := u.getOltConfig(olt, 1, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, u.onuInventoryColumns(olt, oltConfig))
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	ErrOltNotFound      = errors.New("olt not found")
+	ErrNoAvailableOnu   = errors.New("no available ONU found")
+	ErrInvalidThreshold = errors.New("invalid optical threshold")
+	ErrInvalidBoardID   = errors.New("invalid 'board_id' parameter")
+	ErrInvalidPonID     = errors.New("invalid 'pon_id' parameter")
+	ErrInvalidOnuID     = errors.New("invalid 'onu_id' parameter")
+)
+
+// sharedRequestTimeout bounds the SNMP requests shared by the callers of a singleflight key, which do not end with the
+// request of the caller that started them
+const sharedRequestTimeout = 60 * time.Second
+
+// doShared runs fn once for the concurrent callers of the key. fn runs under a copy of ctx without its cancellation,
+// bounded by sharedRequestTimeout, so the caller that started it going away does not cancel the other callers.
+// Each caller stops waiting when its own ctx is done.
+func doShared(
+	ctx context.Context, sg *singleflight.Group, key string, fn func(ctx context.Context) (interface{}, error),
+) (interface{}, error) {
+	resultCh := sg.DoChan(key, func() (interface{}, error) {
+		sharedCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sharedRequestTimeout)
+		defer cancel()
+		return fn(sharedCtx)
+	})
+
+	select {
+	case result := <-resultCh:
+		return result.Val, result.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type OltUseCaseInterface interface {
+	GetOltList() []model.Olt
+	GetSnmpBreaker(oltID string) (model.SnmpBreaker, error)
+	GetSnmpLimiter(oltID string) (model.SnmpLimiter, error)
+	GetOltChassis(ctx context.Context, oltID string) (model.OltChassis, error)
+	GetTopology(oltID string) (model.OltTopology, error)
+	DiscoverTopology(ctx context.Context, oltID string) (model.OltTopology, error)
+	WatchTopology(ctx context.Context)
+}
+
+type oltUsecase struct {
+	oltRepository repository.OltRepositoryInterface
+	cfg           *config.Config
+	sg            singleflight.Group
+}
+
+func NewOltUsecase(oltRepository repository.OltRepositoryInterface, cfg *config.Config) OltUseCaseInterface {
+	return &oltUsecase{
+		oltRepository: oltRepository,
+		cfg:           cfg,
+		sg:            singleflight.Group{},
+	}
+}
+
+// GetOltList is a function to get all OLTs managed by this service
+func (u *oltUsecase) GetOltList() []model.Olt {
+	return u.oltRepository.List()
+}
+
+// GetSnmpBreaker is a function to get the circuit breaker state of the SNMP agent of an OLT
+func (u *oltUsecase) GetSnmpBreaker(oltID string) (model.SnmpBreaker, error) {
+	olt, ok := u.oltRepository.Get(oltID)
+	if !ok {
+		return model.SnmpBreaker{}, ErrOltNotFound
+	}
+
+	stats := olt.Breaker.Stats()
+	breaker := model.SnmpBreaker{
+		OltID:               olt.Olt.ID,
+		State:               string(stats.State),
+		ConsecutiveTimeouts: stats.ConsecutiveTimeouts,
+		Trips:               stats.Trips,
+		CooldownSeconds:     int(stats.Cooldown.Seconds()),
+	}
+
+	// Opened and retry time are only set while the breaker is not closed
+	if !stats.OpenedAt.IsZero() {
+		breaker.OpenedAt = &stats.OpenedAt
+		breaker.RetryAt = &stats.RetryAt
+	}
+
+	return breaker, nil
+}
+
+// GetSnmpLimiter is a function to get the limiter state and queue metrics of the SNMP requests to an OLT
+func (u *oltUsecase) GetSnmpLimiter(oltID string) (model.SnmpLimiter, error) {
+	olt, ok := u.oltRepository.Get(oltID)
+	if !ok {
+		return model.SnmpLimiter{}, ErrOltNotFound
+	}
+
+	stats := olt.Limiter.Stats()
+	limiter := model.SnmpLimiter{
+		OltID:            olt.Olt.ID,
+		MaxInFlight:      stats.MaxInFlight,
+		InFlight:         stats.InFlight,
+		MaxQueue:         stats.MaxQueue,
+		Queued:           stats.Queued,
+		MaxPDUsPerSecond: stats.MaxPDUsPerSecond,
+		Requests:         stats.Requests,
+		QueuedRequests:   stats.Waited,
+		RejectedRequests: stats.Rejected,
+		MaxQueueTimeMs:   durationToMilliseconds(stats.MaxQueueTime),
+		PDUs:             stats.PDUs,
+		RateLimitedPDUs:  stats.RateWaited,
+	}
+
+	// Average waits only over the requests and PDUs that had to wait
+	if stats.Waited > 0 {
+		limiter.AvgQueueTimeMs = durationToMilliseconds(stats.QueueTime / time.Duration(stats.Waited))
+	}
+	if stats.RateWaited > 0 {
+		limiter.AvgRateLimitWaitMs = durationToMilliseconds(stats.RateWait / time.Duration(stats.RateWaited))
+	}
+
+	return limiter, nil
+}
+
+// durationToMilliseconds converts a duration to milliseconds
+func durationToMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/stretchr/testify/assert"
+)
+
+// testUsecases are the usecases of one OLT repository
+type testUsecases struct {
+	onu OnuUseCaseInterface
+	olt OltUseCaseInterface
+}
+
+// TestUsecaseErrors covers the plumbing shared by the usecase methods reading an OLT: the OLT lookup and the errors of
+// the circuit breaker and limiter of its SNMP repository are returned to the handler, which maps them to 404 and 503.
+func TestUsecaseErrors(t *testing.T) {
+	methods := []struct {
+		name string
+		call func(uc testUsecases, oltID string) error
+	}{
+		{"GetByBoardIDAndPonID", func(uc testUsecases, oltID string) error {
+			_, err := uc.onu.GetByBoardIDAndPonID(context.Background(), oltID, 1, 1)
+			return err
+		}},
+		{"GetByBoardIDAndPonIDV2", func(uc testUsecases, oltID string) error {
+			_, err := uc.onu.GetByBoardIDAndPonIDV2(context.Background(), oltID, 1, 1)
+			return err
+		}},
+		{"GetByBoardIDPonIDAndOnuID", func(uc testUsecases, oltID string) error {
+			_, err := uc.onu.GetByBoardIDPonIDAndOnuID(context.Background(), oltID, 1, 1, 1)
+			return err
+		}},
+		{"GetByBoardIDPonIDAndOnuIDV2", func(uc testUsecases, oltID string) error {
+			_, err := uc.onu.GetByBoardIDPonIDAndOnuIDV2(context.Background(), oltID, 1, 1, 1)
+			return err
+		}},
+		{"GetByBoardIDBelowOpticalThreshold", func(uc testUsecases, oltID string) error {
+			_, err := uc.onu.GetByBoardIDBelowOpticalThreshold(context.Background(), oltID, 1, 0, "good")
+			return err
+		}},
+		{"GetByBoardIDBelowOpticalThresholdV2", func(uc testUsecases, oltID string) error {
+			_, err := uc.onu.GetByBoardIDBelowOpticalThresholdV2(context.Background(), oltID, 1, 1, "good")
+			return err
+		}},
+		{"GetByBoardIDAndPonIDWithPagination", func(uc testUsecases, oltID string) error {
+			_, _, err := uc.onu.GetByBoardIDAndPonIDWithPagination(context.Background(), oltID, 1, 1, 1, 10)
+			return err
+		}},
+		{"GetEmptyOnuID", func(uc testUsecases, oltID string) error {
+			_, err := uc.onu.GetEmptyOnuID(context.Background(), oltID, 1, 1)
+			return err
+		}},
+		{"GetOnuIDAndSerialNumber", func(uc testUsecases, oltID string) error {
+			_, err := uc.onu.GetOnuIDAndSerialNumber(context.Background(), oltID, 1, 1)
+			return err
+		}},
+		{"UpdateEmptyOnuID", func(uc testUsecases, oltID string) error {
+			return uc.onu.UpdateEmptyOnuID(context.Background(), oltID, 1, 1)
+		}},
+		{"GetPonPort", func(uc testUsecases, oltID string) error {
+			_, err := uc.onu.GetPonPort(context.Background(), oltID, 1, 1)
+			return err
+		}},
+		{"GetTraffic", func(uc testUsecases, oltID string) error {
+			_, err := uc.onu.GetTraffic(context.Background(), oltID)
+			return err
+		}},
+		{"GetPonTraffic", func(uc testUsecases, oltID string) error {
+			_, err := uc.onu.GetPonTraffic(context.Background(), oltID, 1, 1)
+			return err
+		}},
+		{"GetONUInventory", func(uc testUsecases, oltID string) error {
+			_, err := uc.onu.GetONUInventory(context.Background(), oltID, 1, 1)
+			return err
+		}},
+		{"GetONUUniPorts", func(uc testUsecases, oltID string) error {
+			_, err := uc.onu.GetONUUniPorts(context.Background(), oltID, 1, 1, 1)
+			return err
+		}},
+		{"GetOltChassis", func(uc testUsecases, oltID string) error {
+			_, err := uc.olt.GetOltChassis(context.Background(), oltID)
+			return err
+		}},
+		{"DiscoverTopology", func(uc testUsecases, oltID string) error {
+			_, err := uc.olt.DiscoverTopology(context.Background(), oltID)
+			return err
+		}},
+	}
+
+	errorCases := []struct {
+		name    string
+		oltID   string
+		snmp    repository.SnmpRepositoryInterface
+		wantErr error
+	}{
+		{name: "OLT not found", oltID: "olt-x", wantErr: ErrOltNotFound},
+		{name: "circuit breaker open", snmp: snmpStub{snmp.ErrCircuitOpen}, wantErr: snmp.ErrCircuitOpen},
+		{name: "limiter queue full", snmp: snmpStub{snmp.ErrLimiterQueueFull}, wantErr: snmp.ErrLimiterQueueFull},
+	}
+
+	for _, method := range methods {
+		for _, tc := range errorCases {
+			t.Run(method.name+"/"+tc.name, func(t *testing.T) {
+				cfg := newTestConfig(true)
+				oltRepo := newReplayOltRepository(t, repository.OltConnection{Snmp: tc.snmp})
+
+				uc := testUsecases{onu: NewOnuUsecase(oltRepo, cacheStub{}, cfg), olt: NewOltUsecase(oltRepo, cfg)}
+				assert.ErrorIs(t, method.call(uc, tc.oltID), tc.wantErr)
+			})
+		}
+	}
+}
@@ -6,686 +6,214 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/achyar10/snmp-olt-zte/config"
 	"github.com/achyar10/snmp-olt-zte/internal/model"
 	"github.com/achyar10/snmp-olt-zte/internal/repository"
 	"github.com/achyar10/snmp-olt-zte/internal/utils"
+	"github.com/achyar10/snmp-olt-zte/pkg/cache"
 	"github.com/gosnmp/gosnmp"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/singleflight"
 )
 
 type OnuUseCaseInterface interface {
-	GetByBoardIDAndPonID(ctx context.Context, boardID, ponID int) ([]model.ONUInfoPerBoard, error)
-	GetByBoardIDPonIDAndOnuID(boardID, ponID, onuID int) (model.ONUCustomerInfo, error)
-	GetEmptyOnuID(ctx context.Context, boardID, ponID int) ([]model.OnuID, error)
-	GetOnuIDAndSerialNumber(boardID, ponID int) ([]model.OnuSerialNumber, error)
-	UpdateEmptyOnuID(ctx context.Context, boardID, ponID int) error
-	GetByBoardIDAndPonIDWithPagination(boardID, ponID, page, pageSize int) (
-		[]model.ONUInfoPerBoard, int,
+	GetByBoardIDAndPonID(ctx context.Context, oltID string, boardID, ponID int) ([]model.ONUInfoPerBoard, error)
+	GetByBoardIDPonIDAndOnuID(ctx context.Context, oltID string, boardID, ponID, onuID int) (model.ONUCustomerInfo, error)
+	GetByBoardIDAndPonIDV2(ctx context.Context, oltID string, boardID, ponID int) ([]model.ONUInfoPerBoardV2, error)
+	GetByBoardIDPonIDAndOnuIDV2(ctx context.Context, oltID string, boardID, ponID, onuID int) (model.ONUCustomerInfoV2, error)
+	GetByBoardIDBelowOpticalThreshold(ctx context.Context, oltID string, boardID, ponID int, below string) ([]model.ONUInfoPerBoard, error)
+	GetByBoardIDBelowOpticalThresholdV2(ctx context.Context, oltID string, boardID, ponID int, below string) ([]model.ONUInfoPerBoardV2, error)
+	GetPonPort(ctx context.Context, oltID string, boardID, ponID int) (model.PonPort, error)
+	GetTraffic(ctx context.Context, oltID string) ([]model.InterfaceTraffic, error)
+	GetPonTraffic(ctx context.Context, oltID string, boardID, ponID int) (model.InterfaceTraffic, error)
+	GetONUInventory(ctx context.Context, oltID string, boardID, ponID int) ([]model.ONUInventoryItem, error)
+	GetONUUniPorts(ctx context.Context, oltID string, boardID, ponID, onuID int) (model.ONUUniPorts, error)
+	GetEmptyOnuID(ctx context.Context, oltID string, boardID, ponID int) ([]model.OnuID, error)
+	GetOnuIDAndSerialNumber(ctx context.Context, oltID string, boardID, ponID int) ([]model.OnuSerialNumber, error)
+	UpdateEmptyOnuID(ctx context.Context, oltID string, boardID, ponID int) error
+	GetByBoardIDAndPonIDWithPagination(ctx context.Context, oltID string, boardID, ponID, page, pageSize int) (
+		[]model.ONUInfoPerBoard, int, error,
 	)
+	GetUnactivatedONU(ctx context.Context, oltID string) ([]model.ONUItem, error)
+	ActivateONU(ctx context.Context, oltID string, slot, port int, request model.ActivateONURequest) (int, string, error)
+	ValidateTopology(oltID string, boardID, ponID, onuID int) error
 }
 
 type onuUsecase struct {
-	snmpRepository  repository.SnmpRepositoryInterface
-	redisRepository repository.OnuRedisRepositoryInterface
-	cfg             *config.Config
-	sg              singleflight.Group
+	oltRepository  repository.OltRepositoryInterface
+	onuInfoCache   cache.Cache[[]model.ONUInfoPerBoardV2]
+	onuIDCache     cache.Cache[[]model.OnuID]
+	onlyOnuIDCache cache.Cache[[]model.OnuOnlyID]
+	trafficCache   cache.Cache[[]model.TrafficSample]
+	cfg            *config.Config
+	sg             singleflight.Group
 }
 
 func NewOnuUsecase(
-	snmpRepository repository.SnmpRepositoryInterface, redisRepository repository.OnuRedisRepositoryInterface,
+	oltRepository repository.OltRepositoryInterface, cacheBackend cache.Backend,
 	cfg *config.Config,
 ) OnuUseCaseInterface {
 	return &onuUsecase{
-		snmpRepository:  snmpRepository,
-		redisRepository: redisRepository,
-		cfg:             cfg,
-		sg:              singleflight.Group{},
+		oltRepository:  oltRepository,
+		onuInfoCache:   cache.New[[]model.ONUInfoPerBoardV2](cacheBackend),
+		onuIDCache:     cache.New[[]model.OnuID](cacheBackend),
+		onlyOnuIDCache: cache.New[[]model.OnuOnlyID](cacheBackend),
+		trafficCache:   cache.New[[]model.TrafficSample](cacheBackend),
+		cfg:            cfg,
+		sg:             singleflight.Group{},
 	}
 }
 
-// getOltInfo is a function to get OLT information
-func (u *onuUsecase) getOltConfig(boardID, ponID int) (*model.OltConfig, error) {
-	cfg, err := u.getBoardConfig(boardID, ponID)
-	if err != nil {
-		log.Error().Msg(err.Error())
-		return nil, err
-	}
-	return cfg, nil
+// onuCacheTTL is the time the ONU lists of a PON are cached
+const onuCacheTTL = 5 * time.Minute
+
+// emptyOnuIDCacheKey returns the cache key of the empty ONU IDs of a PON
+func emptyOnuIDCacheKey(oltID string, boardID, ponID int) string {
+	return "olt_" + oltID + "_board_" + strconv.Itoa(boardID) + "_pon_" + strconv.Itoa(ponID) + "_empty_onu_id"
 }
 
-// getBoardConfig is a function to get board configuration
-func (u *onuUsecase) getBoardConfig(boardID, ponID int) (*model.OltConfig, error) {
-	switch boardID {
-	case 1:
-		return u.getBoard1Config(ponID), nil
-	case 2:
-		return u.getBoard2Config(ponID), nil
-	default:
-		return nil, errors.New("invalid Board ID")
+// getOlt is a function to get the OLT connection, the default OLT if oltID is empty
+func (u *onuUsecase) getOlt(oltID string) (*repository.OltConnection, error) {
+	olt, ok := u.oltRepository.Get(oltID)
+	if !ok {
+		log.Error().Msg("OLT not found: " + oltID)
+		return nil, ErrOltNotFound
 	}
+	return olt, nil
 }
 
-// getBoard1Config is a function to get board 1 configuration
-func (u *onuUsecase) getBoard1Config(ponID int) *model.OltConfig {
-	// Define the configuration for Board 1
-	switch ponID {
-	case 1:
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon1.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon1.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon1.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon1.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon1.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon1.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon1.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon1.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon1.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon1.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon1.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon1.OnuGponOpticalDistanceOID,
-		}
-	case 2:
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon2.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon2.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon2.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon2.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon2.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon2.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon2.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon2.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon2.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon2.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon2.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon2.OnuGponOpticalDistanceOID,
-		}
-	case 3: // PON 3
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon3.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon3.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon3.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon3.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon3.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon3.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon3.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon3.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon3.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon3.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon3.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon3.OnuGponOpticalDistanceOID,
-		}
-	case 4: // PON 4
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon4.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon4.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon4.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon4.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon4.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon4.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon4.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon4.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon4.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon4.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon4.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon4.OnuGponOpticalDistanceOID,
-		}
-	case 5: // PON 5
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon5.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon5.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon5.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon5.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon5.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon5.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon5.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon5.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon5.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon5.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon5.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon5.OnuGponOpticalDistanceOID,
-		}
-	case 6: // PON 6
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon6.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon6.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon6.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon6.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon6.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon6.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon6.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon6.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon6.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon6.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon6.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon6.OnuGponOpticalDistanceOID,
-		}
-	case 7: // PON 7
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon7.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon7.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon7.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon7.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon7.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon7.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon7.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon7.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon7.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon7.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon7.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon7.OnuGponOpticalDistanceOID,
-		}
-	case 8: // PON 8
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon8.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon8.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon8.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon8.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon8.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon8.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon8.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon8.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon8.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon8.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon8.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon8.OnuGponOpticalDistanceOID,
-		}
-	case 9: // PON 9
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon9.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon9.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon9.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon9.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon9.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon9.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon9.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon9.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon9.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon9.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon9.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon9.OnuGponOpticalDistanceOID,
-		}
-	case 10: // PON 10
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon10.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon10.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon10.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon10.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon10.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon10.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon10.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon10.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon10.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon10.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon10.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon10.OnuGponOpticalDistanceOID,
-		}
-	case 11: // PON 11
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon11.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon11.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon11.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon11.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon11.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon11.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon11.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon11.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon11.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon11.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon11.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon11.OnuGponOpticalDistanceOID,
-		}
-	case 12: // PON 12
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon12.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon12.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon12.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon12.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon12.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon12.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon12.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon12.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon12.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon12.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon12.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon12.OnuGponOpticalDistanceOID,
-		}
-	case 13: // PON 13
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon13.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon13.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon13.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon13.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon13.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon13.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon13.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon13.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon13.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon13.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon13.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon13.OnuGponOpticalDistanceOID,
-		}
-	case 14: // PON 14
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon14.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon14.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon14.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon14.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon14.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon14.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon14.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon14.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon14.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon14.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon14.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon14.OnuGponOpticalDistanceOID,
-		}
-	case 15: // PON 15
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon15.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon15.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon15.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon15.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon15.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon15.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon15.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon15.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon15.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon15.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon15.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon15.OnuGponOpticalDistanceOID,
-		}
-
-	case 16: // PON 16
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board1Pon16.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board1Pon16.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board1Pon16.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board1Pon16.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board1Pon16.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board1Pon16.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board1Pon16.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board1Pon16.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board1Pon16.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board1Pon16.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board1Pon16.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board1Pon16.OnuGponOpticalDistanceOID,
-		}
+// getRackShelf returns the rack and shelf of the boards of an OLT, 1 when they are not configured
+func (u *onuUsecase) getRackShelf(olt *repository.OltConnection) (rack, shelf int) {
+	return getRackShelf(getOltOID(u.cfg, olt))
+}
 
-	default:
-		log.Error().Msg("Invalid PON ID") // Log error message
-		return nil
+// getOltConfig is a function to get the OIDs of an OLT for the given board (card slot) and PON (port)
+func (u *onuUsecase) getOltConfig(olt *repository.OltConnection, boardID, ponID int) (*model.OltConfig, error) {
+	if boardID < 1 || ponID < 1 {
+		log.Error().Msg("Invalid Board ID or PON ID")
+		return nil, errors.New("invalid Board ID or PON ID")
 	}
-}
 
-// getBoard2Config is a function to get board 2 configuration
-func (u *onuUsecase) getBoard2Config(ponID int) *model.OltConfig {
-	// Define the configuration for Board 2
-	switch ponID {
-	case 1: // PON 1
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon1.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon1.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon1.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon1.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon1.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon1.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon1.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon1.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon1.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon1.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon1.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon1.OnuGponOpticalDistanceOID,
-		}
-	case 2: // PON 2
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon2.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon2.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon2.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon2.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon2.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon2.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon2.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon2.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon2.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon2.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon2.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon2.OnuGponOpticalDistanceOID,
-		}
-	case 3: // PON 3
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon3.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon3.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon3.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon3.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon3.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon3.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon3.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon3.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon3.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon3.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon3.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon3.OnuGponOpticalDistanceOID,
-		}
-	case 4: // PON 4
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon4.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon4.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon4.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon4.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon4.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon4.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon4.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon4.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon4.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon4.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon4.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon4.OnuGponOpticalDistanceOID,
-		}
-	case 5: // PON 5
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon5.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon5.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon5.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon5.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon5.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon5.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon5.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon5.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon5.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon5.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon5.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon5.OnuGponOpticalDistanceOID,
-		}
-	case 6: // PON 6
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon6.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon6.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon6.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon6.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon6.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon6.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon6.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon6.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon6.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon6.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon6.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon6.OnuGponOpticalDistanceOID,
-		}
-	case 7: // PON 7
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon7.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon7.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon7.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon7.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon7.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon7.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon7.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon7.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon7.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon7.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon7.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon7.OnuGponOpticalDistanceOID,
-		}
-	case 8: // PON 8
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon8.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon8.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon8.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon8.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon8.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon8.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon8.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon8.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon8.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon8.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon8.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon8.OnuGponOpticalDistanceOID,
-		}
-	case 9: // PON 9
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon9.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon9.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon9.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon9.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon9.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon9.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon9.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon9.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon9.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon9.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon9.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon9.OnuGponOpticalDistanceOID,
-		}
-	case 10: // PON 10
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon10.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon10.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon10.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon10.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon10.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon10.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon10.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon10.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon10.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon10.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon10.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon10.OnuGponOpticalDistanceOID,
-		}
-	case 11: // PON 11
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon11.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon11.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon11.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon11.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon11.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon11.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon11.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon11.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon11.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon11.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon11.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon11.OnuGponOpticalDistanceOID,
-		}
+	oid := getOltOID(u.cfg, olt)
+	rack, shelf := getRackShelf(oid)
+
+	// Calculate the indexes used as suffix of the per-PON OIDs
+	ifIndex := utils.GponIfIndex(rack, shelf, boardID, ponID)
+	ponIndex := utils.GponPonIndex(shelf, boardID, ponID)
+
+	tpl := oid.PonOID
+	return &model.OltConfig{
+		BaseOID:                   oid.BaseOID1,
+		OnuIDNameOID:              utils.BuildPonOID(tpl.OnuIDNameOID, ifIndex, ponIndex),
+		OnuTypeOID:                utils.BuildPonOID(tpl.OnuTypeOID, ifIndex, ponIndex),
+		OnuSerialNumberOID:        utils.BuildPonOID(tpl.OnuSerialNumberOID, ifIndex, ponIndex),
+		OnuRxPowerOID:             utils.BuildPonOID(tpl.OnuRxPowerOID, ifIndex, ponIndex),
+		OnuOltRxPowerOID:          utils.BuildPonOID(tpl.OnuOltRxPowerOID, ifIndex, ponIndex),
+		OnuTxPowerOID:             utils.BuildPonOID(tpl.OnuTxPowerOID, ifIndex, ponIndex),
+		OnuTemperatureOID:         utils.BuildPonOID(tpl.OnuTemperatureOID, ifIndex, ponIndex),
+		OnuVoltageOID:             utils.BuildPonOID(tpl.OnuVoltageOID, ifIndex, ponIndex),
+		OnuBiasCurrentOID:         utils.BuildPonOID(tpl.OnuBiasCurrentOID, ifIndex, ponIndex),
+		OnuStatusOID:              utils.BuildPonOID(tpl.OnuStatusOID, ifIndex, ponIndex),
+		OnuIPAddressOID:           utils.BuildPonOID(tpl.OnuIPAddressOID, ifIndex, ponIndex),
+		OnuDescriptionOID:         utils.BuildPonOID(tpl.OnuDescriptionOID, ifIndex, ponIndex),
+		OnuLastOnlineOID:          utils.BuildPonOID(tpl.OnuLastOnlineOID, ifIndex, ponIndex),
+		OnuLastOfflineOID:         utils.BuildPonOID(tpl.OnuLastOfflineOID, ifIndex, ponIndex),
+		OnuLastOfflineReasonOID:   utils.BuildPonOID(tpl.OnuLastOfflineReasonOID, ifIndex, ponIndex),
+		OnuGponOpticalDistanceOID: utils.BuildPonOID(tpl.OnuGponOpticalDistanceOID, ifIndex, ponIndex),
+		OnuUpstreamBytesOID:       utils.BuildPonOID(tpl.OnuUpstreamBytesOID, ifIndex, ponIndex),
+		OnuDownstreamBytesOID:     utils.BuildPonOID(tpl.OnuDownstreamBytesOID, ifIndex, ponIndex),
+		OnuUpstreamPacketsOID:     utils.BuildPonOID(tpl.OnuUpstreamPacketsOID, ifIndex, ponIndex),
+		OnuDownstreamPacketsOID:   utils.BuildPonOID(tpl.OnuDownstreamPacketsOID, ifIndex, ponIndex),
+		OnuVendorIDOID:            utils.BuildPonOID(tpl.OnuVendorIDOID, ifIndex, ponIndex),
+		OnuEquipmentIDOID:         utils.BuildPonOID(tpl.OnuEquipmentIDOID, ifIndex, ponIndex),
+		OnuHardwareVersionOID:     utils.BuildPonOID(tpl.OnuHardwareVersionOID, ifIndex, ponIndex),
+		OnuActiveSwVersionOID:     utils.BuildPonOID(tpl.OnuActiveSwVersionOID, ifIndex, ponIndex),
+		OnuStandbySwVersionOID:    utils.BuildPonOID(tpl.OnuStandbySwVersionOID, ifIndex, ponIndex),
+		OnuMacAddressOID:          utils.BuildPonOID(tpl.OnuMacAddressOID, ifIndex, ponIndex),
+		OnuEthAdminStateOID:       utils.BuildPonOID(tpl.OnuEthAdminStateOID, ifIndex, ponIndex),
+		OnuEthLinkStatusOID:       utils.BuildPonOID(tpl.OnuEthLinkStatusOID, ifIndex, ponIndex),
+		OnuEthSpeedDuplexOID:      utils.BuildPonOID(tpl.OnuEthSpeedDuplexOID, ifIndex, ponIndex),
+	}, nil
+}
 
-	case 12: // PON 12
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon12.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon12.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon12.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon12.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon12.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon12.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon12.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon12.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon12.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon12.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon12.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon12.OnuGponOpticalDistanceOID,
-		}
+func (u *onuUsecase) GetByBoardIDAndPonID(ctx context.Context, oltID string, boardID, ponID int) ([]model.ONUInfoPerBoard, error) {
+	onuInformationList, err := u.getONUInfoList(ctx, oltID, boardID, ponID)
+	if err != nil {
+		return nil, err
+	}
 
-	case 13: // PON 13
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon13.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon13.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon13.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon13.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon13.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon13.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon13.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon13.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon13.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon13.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon13.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon13.OnuGponOpticalDistanceOID,
-		}
-	case 14: // PON 14
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon14.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon14.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon14.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon14.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon14.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon14.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon14.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon14.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon14.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon14.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon14.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon14.OnuGponOpticalDistanceOID,
-		}
-	case 15: // PON 15
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon15.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon15.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon15.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon15.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon15.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon15.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon15.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon15.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon15.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon15.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon15.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon15.OnuGponOpticalDistanceOID,
-		}
-	case 16: // PON 16
-		return &model.OltConfig{
-			BaseOID:                   u.cfg.OltCfg.BaseOID1,
-			OnuIDNameOID:              u.cfg.Board2Pon16.OnuIDNameOID,
-			OnuTypeOID:                u.cfg.Board2Pon16.OnuTypeOID,
-			OnuSerialNumberOID:        u.cfg.Board2Pon16.OnuSerialNumberOID,
-			OnuRxPowerOID:             u.cfg.Board2Pon16.OnuRxPowerOID,
-			OnuTxPowerOID:             u.cfg.Board2Pon16.OnuTxPowerOID,
-			OnuStatusOID:              u.cfg.Board2Pon16.OnuStatusOID,
-			OnuIPAddressOID:           u.cfg.Board2Pon16.OnuIPAddressOID,
-			OnuDescriptionOID:         u.cfg.Board2Pon16.OnuDescriptionOID,
-			OnuLastOnlineOID:          u.cfg.Board2Pon16.OnuLastOnlineOID,
-			OnuLastOfflineOID:         u.cfg.Board2Pon16.OnuLastOfflineOID,
-			OnuLastOfflineReasonOID:   u.cfg.Board2Pon16.OnuLastOfflineReasonOID,
-			OnuGponOpticalDistanceOID: u.cfg.Board2Pon16.OnuGponOpticalDistanceOID,
-		}
-	default:
-		log.Error().Msg("Invalid PON ID") // Log error message
-		return nil
+	// Convert the typed ONU information to its string representation
+	result := make([]model.ONUInfoPerBoard, len(onuInformationList))
+	for i, onuInfo := range onuInformationList {
+		result[i] = toONUInfoPerBoard(onuInfo)
 	}
+	return result, nil
 }
 
-func (u *onuUsecase) GetByBoardIDAndPonID(ctx context.Context, boardID, ponID int) ([]model.ONUInfoPerBoard, error) {
+func (u *onuUsecase) GetByBoardIDAndPonIDV2(ctx context.Context, oltID string, boardID, ponID int) ([]model.ONUInfoPerBoardV2, error) {
+	return u.getONUInfoList(ctx, oltID, boardID, ponID)
+}
+
+// getONUInfoList is a function to get the typed ONU information of a PON, from the cache or SNMP
+func (u *onuUsecase) getONUInfoList(ctx context.Context, oltID string, boardID, ponID int) ([]model.ONUInfoPerBoardV2, error) {
 	log.Info().Msg("Get All ONU Information from Board ID: " + strconv.Itoa(boardID) + " and PON ID: " + strconv.Itoa(ponID))
 
-	key := fmt.Sprintf("onuinfo-b%d-p%d", boardID, ponID)
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("onuinfo-%s-b%d-p%d", olt.Olt.ID, boardID, ponID)
 
 	// Using simple flight to prevent duplicate SNMP requests
-	result, err, _ := u.sg.Do(key, func() (interface{}, error) {
+	result, err := doShared(ctx, &u.sg, key, func(ctx context.Context) (interface{}, error) {
 		// Get OLT config
-		oltConfig, err := u.getOltConfig(boardID, ponID) // Get OLT config based on Board ID and PON ID
+		oltConfig, err := u.getOltConfig(olt, boardID, ponID) // Get OLT config based on Board ID and PON ID
 		if err != nil {
 			log.Error().Msg("Failed to get OLT Config: " + err.Error())
 			return nil, err
 		}
 
-		// Redis key
-		redisKey := fmt.Sprintf("board_%d_pon_%d", boardID, ponID)
+		// Cache key
+		cacheKey := fmt.Sprintf("onu_info_olt_%s_board_%d_pon_%d", olt.Olt.ID, boardID, ponID)
 
-		// Check if data is already cached in Redis
-		cachedOnuData, err := u.redisRepository.GetONUInfoList(ctx, redisKey) // Get ONU Information from Redis
+		// Check if data is already cached
+		cachedOnuData, err := u.onuInfoCache.Get(ctx, cacheKey) // Get ONU Information from the cache
 		if err == nil && cachedOnuData != nil {
-			log.Info().Msg("Get ONU Information from Redis with Key: " + redisKey)
+			log.Info().Msg("Get ONU Information from cache with Key: " + cacheKey)
 			return cachedOnuData, nil
 		}
 
 		// SNMP Walk to get Information from OLT Board and PON
 		log.Info().Msg("Get All ONU Information from SNMP Walk Board ID: " + strconv.Itoa(boardID) + " and PON ID: " + strconv.Itoa(ponID))
-		// Create a map to store SNMP Walk results
-		snmpDataMap := make(map[string]gosnmp.SnmpPDU)
-		// Perform SNMP Walk to get ONU ID and Name using snmpRepository Walk method with timeout context parameter
-		err = u.snmpRepository.Walk(oltConfig.BaseOID+oltConfig.OnuIDNameOID, func(pdu gosnmp.SnmpPDU) error {
-			snmpDataMap[utils.ExtractONUID(pdu.Name)] = pdu
-			return nil
-		})
 
+		var onuInformationList []model.ONUInfoPerBoardV2 // Create a slice of ONUInfoPerBoardV2
+
+		// Fetch whole columns with BulkWalk in table fetch mode, otherwise Get every attribute per ONU
+		if u.cfg.OltCfg.TableFetch {
+			onuInformationList, err = u.getONUInfoTable(ctx, olt, oltConfig, boardID, ponID)
+		} else {
+			onuInformationList, err = u.getONUInfoPerOnu(ctx, olt, oltConfig, boardID, ponID)
+		}
 		if err != nil {
 			return nil, err
 		}
 
-		var onuInformationList []model.ONUInfoPerBoard // Create a slice of ONUInfoPerBoard
-
-		// Loop through SNMP data map to get ONU information based on ONU ID and ONU Name stored in map before and store
-		for _, pdu := range snmpDataMap {
-			onuInfo := model.ONUInfoPerBoard{
-				Board: boardID,
-				PON:   ponID,
-				ID:    utils.ExtractIDOnuID(pdu.Name),
-				Name:  utils.ExtractName(pdu.Value),
-			}
-
-			// Get Data ONU Type from SNMP Walk using getONUType method
-			if onuType, err := u.getONUType(oltConfig.OnuTypeOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.OnuType = onuType
-			}
-			// Get Data ONU Serial Number from SNMP Walk using getSerialNumber method
-			if sn, err := u.getSerialNumber(oltConfig.OnuSerialNumberOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.SerialNumber = sn
-			}
-			// Get Data ONU RX Power from SNMP Walk using getRxPower method
-			if rx, err := u.getRxPower(oltConfig.OnuRxPowerOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.RXPower = rx
-			}
-			// Get Data ONU TX Power from SNMP Walk using getTxPower method
-			if status, err := u.getStatus(oltConfig.OnuStatusOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.Status = status
-			}
-
-			// Get Data ONU IP Address from SNMP Walk using getIPAddress method
-			onuInformationList = append(onuInformationList, onuInfo)
-		}
-
 		// Sort the ONU information list by ID
 		sort.Slice(onuInformationList, func(i, j int) bool {
 			return onuInformationList[i].ID < onuInformationList[j].ID
 		})
 
-		// Save the ONU information list to Redis with a 5-minute expiration time
-		err = u.redisRepository.SaveONUInfoList(ctx, redisKey, 300, onuInformationList)
+		// Save the ONU information list to the cache with a 5-minute expiration time
+		err = u.onuInfoCache.Set(ctx, cacheKey, onuInformationList, onuCacheTTL)
 		if err != nil {
-			log.Error().Msg("Failed to save ONU Information to Redis: " + err.Error())
+			log.Error().Msg("Failed to save ONU Information to cache: " + err.Error())
 		} else {
-			log.Info().Msg("Saved ONU Information to Redis with Key: " + redisKey)
+			log.Info().Msg("Saved ONU Information to cache with Key: " + cacheKey)
 		}
 
 		// Return the ONU information list
@@ -697,149 +225,340 @@ func (u *onuUsecase) GetByBoardIDAndPonID(ctx context.Context, boardID, ponID in
 		return nil, err                                                  // Return error if error is not nil
 	}
 
-	return result.([]model.ONUInfoPerBoard), nil // Return the result from the cache or SNMP Walk
+	// Classify the signal quality of a copy, the result from the cache or SNMP Walk may be shared by singleflight
+	onuInformationList := append([]model.ONUInfoPerBoardV2(nil), result.([]model.ONUInfoPerBoardV2)...)
+	for i := range onuInformationList {
+		onuInfo := &onuInformationList[i]
+		onuInfo.SignalQuality = classifySignalQuality(olt.Optical, onuInfo.OnuType, onuInfo.RXPower)
+	}
+
+	return onuInformationList, nil
 }
 
-func (u *onuUsecase) GetByBoardIDPonIDAndOnuID(boardID, ponID, onuID int) (
-	model.ONUCustomerInfo, error,
-) {
-	// Set key for simple flight
-	key := fmt.Sprintf("onu:%d:%d:%d", boardID, ponID, onuID)
+// getONUInfoPerOnu is a function to get the ONU information of a PON with one SNMP Get per ONU attribute
+func (u *onuUsecase) getONUInfoPerOnu(
+	ctx context.Context, olt *repository.OltConnection, oltConfig *model.OltConfig, boardID, ponID int,
+) ([]model.ONUInfoPerBoardV2, error) {
+	// Create a map to store SNMP Walk results
+	snmpDataMap := make(map[string]gosnmp.SnmpPDU)
+	// Perform SNMP Walk to get ONU ID and Name using snmpRepository Walk method with timeout context parameter
+	err := olt.Snmp.Walk(ctx, oltConfig.BaseOID+oltConfig.OnuIDNameOID, func(pdu gosnmp.SnmpPDU) error {
+		snmpDataMap[utils.ExtractONUID(pdu.Name)] = pdu
+		return nil
+	})
 
-	// Using simple flight to prevent duplicate SNMP requests
-	result, err, _ := u.sg.Do(key, func() (interface{}, error) {
-		oltConfig, err := u.getOltConfig(boardID, ponID) // Get OLT config based on Board ID and PON ID
-		if err != nil {
-			log.Error().Msg("Failed to get OLT Config: " + err.Error())
-			return model.ONUCustomerInfo{}, err
-		}
+	if err != nil {
+		return nil, err
+	}
 
-		var onuInformationList model.ONUCustomerInfo   // Create a variable to store ONU information
-		snmpDataMap := make(map[string]gosnmp.SnmpPDU) // Create a map to store SNMP Walk results
+	var onuInformationList []model.ONUInfoPerBoardV2 // Create a slice of ONUInfoPerBoardV2
 
-		log.Info().Msg("Get Detail ONU Information with SNMP Walk from Board ID: " +
-			strconv.Itoa(boardID) + " PON ID: " + strconv.Itoa(ponID) +
-			" ONU ID: " + strconv.Itoa(onuID))
+	// Loop through SNMP data map to get ONU information based on ONU ID and ONU Name stored in map before and store
+	for _, pdu := range snmpDataMap {
+		// Stop fetching the remaining ONUs when the request is canceled
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 
-		// Get ONU ID and Name using snmpRepository Walk method with timeout context parameter
-		err = u.snmpRepository.Walk(oltConfig.BaseOID+oltConfig.OnuIDNameOID+"."+strconv.Itoa(onuID),
-			func(pdu gosnmp.SnmpPDU) error {
-				snmpDataMap[utils.ExtractONUID(pdu.Name)] = pdu
-				return nil
-			})
-		if err != nil {
-			log.Error().Msg("Failed to walk OID: " + err.Error())
-			return model.ONUCustomerInfo{}, errors.New("failed to walk OID")
+		onuInfo := model.ONUInfoPerBoardV2{
+			Board: boardID,
+			PON:   ponID,
+			ID:    utils.ExtractIDOnuID(pdu.Name),
+			Name:  utils.ExtractName(pdu.Value),
+		}
+
+		// Get the ONU type, serial number, RX power of the ONU and of the OLT and status with one SNMP Get each
+		if err := u.getONUInfoColumns(ctx, olt, oltConfig, &onuInfo); err != nil {
+			return nil, err
 		}
 
-		// Loop through SNMP data map to get ONU information based on ONU ID and ONU Name stored in map before and store
-		for _, pdu := range snmpDataMap {
-			onuInfo := model.ONUCustomerInfo{
+		onuInformationList = append(onuInformationList, onuInfo)
+	}
+
+	return onuInformationList, nil
+}
+
+// getONUInfoTable is a function to get the ONU information of a PON with one SNMP BulkWalk per column,
+// joining the columns by ONU ID
+func (u *onuUsecase) getONUInfoTable(
+	ctx context.Context, olt *repository.OltConnection, oltConfig *model.OltConfig, boardID, ponID int,
+) ([]model.ONUInfoPerBoardV2, error) {
+	onuInfoMap := make(map[int]*model.ONUInfoPerBoardV2) // Create a map to store ONU information by ONU ID
+
+	// BulkWalk the ONU name column first, every registered ONU has a name
+	nameOID := oltConfig.BaseOID + oltConfig.OnuIDNameOID
+	err := olt.Snmp.BulkWalk(ctx, nameOID, func(pdu gosnmp.SnmpPDU) error {
+		if onuID, ok := utils.ExtractOnuIndex(pdu.Name, nameOID, ""); ok {
+			onuInfoMap[onuID] = &model.ONUInfoPerBoardV2{
 				Board: boardID,
 				PON:   ponID,
-				ID:    utils.ExtractIDOnuID(pdu.Name),
+				ID:    onuID,
 				Name:  utils.ExtractName(pdu.Value),
 			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			// Get Data ONU Type from SNMP Walk using getONUType method
-			if onuType, err := u.getONUType(oltConfig.OnuTypeOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.OnuType = onuType
+	// Columns joined to the ONU name by ONU ID
+	for _, column := range u.onuInfoColumns(olt, oltConfig) {
+		err := olt.Snmp.BulkWalk(ctx, column.oid, func(pdu gosnmp.SnmpPDU) error {
+			onuID, ok := utils.ExtractOnuIndex(pdu.Name, column.oid, column.suffix)
+			if !ok {
+				return nil
 			}
-
-			// Get Data ONU Serial Number from SNMP Walk using getSerialNumber method
-			if serial, err := u.getSerialNumber(oltConfig.OnuSerialNumberOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.SerialNumber = serial
+			if onuInfo, ok := onuInfoMap[onuID]; ok {
+				column.decode(pdu.Value, onuInfo)
 			}
+			return nil
+		})
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if err != nil {
+			// A listing without a column is incomplete, it must not be cached
+			log.Error().Msg("Failed to perform SNMP BulkWalk for OID " + column.oid + ": " + err.Error())
+			return nil, fmt.Errorf("failed to perform SNMP BulkWalk: %w", err)
+		}
+	}
 
-			// Get Data ONU RX Power from SNMP Walk using getRxPower method
-			if rx, err := u.getRxPower(oltConfig.OnuRxPowerOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.RXPower = rx
-			}
+	onuInformationList := make([]model.ONUInfoPerBoardV2, 0, len(onuInfoMap))
+	for _, onuInfo := range onuInfoMap {
+		onuInformationList = append(onuInformationList, *onuInfo)
+	}
 
-			// Get Data ONU TX Power from SNMP Walk using getTxPower method
-			if tx, err := u.getTxPower(oltConfig.OnuTxPowerOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.TXPower = tx
-			}
+	return onuInformationList, nil
+}
 
-			// Get Data ONU Status from SNMP Walk using getStatus method
-			if status, err := u.getStatus(oltConfig.OnuStatusOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.Status = status
-			}
+// onuInfoColumn is a per-PON column of the ONU listing with the decoder of its ONUInfoPerBoardV2 field
+type onuInfoColumn struct {
+	oid    string // Column OID, the ONU ID and the suffix are appended per ONU
+	suffix string // Part of the OID after the ONU ID
+	decode func(value interface{}, info *model.ONUInfoPerBoardV2)
+}
 
-			// Get Data ONU IP Address from SNMP Walk using getIPAddress method
-			if ip, err := u.getIPAddress(oltConfig.OnuIPAddressOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.IPAddress = ip
-			}
+// onuInfoColumns returns the configured columns of the ONU listing besides the ONU name
+func (u *onuUsecase) onuInfoColumns(olt *repository.OltConnection, oltConfig *model.OltConfig) []onuInfoColumn {
+	oltOID := getOltOID(u.cfg, olt)
 
-			// Get Data ONU Description from SNMP Walk using getDescription method
-			if desc, err := u.getDescription(oltConfig.OnuDescriptionOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.Description = desc
-			}
+	// oid returns the OID of a listing column, empty when the column is not configured
+	oid := func(baseOID, attributeOID string) string {
+		if attributeOID == "" {
+			return ""
+		}
+		return baseOID + attributeOID
+	}
 
-			// Get Data ONU Last Online from SNMP Walk using getLastOnline method
-			if lastOnline, err := u.getLastOnline(oltConfig.OnuLastOnlineOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.LastOnline = lastOnline
+	columns := []onuInfoColumn{
+		{oid(oltOID.BaseOID2, oltConfig.OnuTypeOID), "", func(value interface{}, info *model.ONUInfoPerBoardV2) {
+			info.OnuType = utils.ExtractName(value)
+		}},
+		{oid(oltOID.BaseOID1, oltConfig.OnuSerialNumberOID), "", func(value interface{}, info *model.ONUInfoPerBoardV2) {
+			info.SerialNumber = utils.ExtractSerialNumber(value)
+		}},
+		{oid(oltOID.BaseOID1, oltConfig.OnuRxPowerOID), ".1", func(value interface{}, info *model.ONUInfoPerBoardV2) {
+			if power, err := extractOpticalPower(olt.Optical, value); err == nil {
+				info.RXPower = power
 			}
-
-			// Get Data ONU Last Offline from SNMP Walk using getLastOffline method
-			if lastOffline, err := u.getLastOffline(oltConfig.OnuLastOfflineOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.LastOffline = lastOffline
+		}},
+		{oid(oltOID.BaseOID1, oltConfig.OnuOltRxPowerOID), "", func(value interface{}, info *model.ONUInfoPerBoardV2) {
+			if power, err := extractOpticalPower(olt.Optical, value); err == nil {
+				info.OltRXPower = power
 			}
-
-			// Get Data ONU Last Offline Reason from SNMP Walk using getLastOfflineReason method
-			if uptime, err := u.getUptimeDuration(onuInfo.LastOnline); err == nil {
-				onuInfo.Uptime = uptime
+		}},
+		{oid(oltOID.BaseOID1, oltConfig.OnuStatusOID), "", func(value interface{}, info *model.ONUInfoPerBoardV2) {
+			info.Status = utils.ExtractAndGetStatus(value)
+			if code, ok := utils.ExtractInteger(value); ok {
+				info.StatusCode = &code
 			}
+		}},
+	}
 
-			// Get Data ONU Last Down Time Duration from SNMP Walk using getLastDownDuration method
-			if downtime, err := u.getLastDownDuration(onuInfo.LastOffline, onuInfo.LastOnline); err == nil {
-				onuInfo.LastDownTimeDuration = downtime
-			}
+	// Skip the columns that are not configured
+	configured := columns[:0]
+	for _, column := range columns {
+		if column.oid != "" {
+			configured = append(configured, column)
+		}
+	}
+	return configured
+}
 
-			// Get Data ONU Last Offline Reason from SNMP Walk using getLastOfflineReason method
-			if reason, err := u.getLastOfflineReason(oltConfig.OnuLastOfflineReasonOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.LastOfflineReason = reason
-			}
+// getONUInfoColumns is a function to get the listing columns of one ONU with one SNMP Get per column
+func (u *onuUsecase) getONUInfoColumns(
+	ctx context.Context, olt *repository.OltConnection, oltConfig *model.OltConfig, onuInfo *model.ONUInfoPerBoardV2,
+) error {
+	for _, column := range u.onuInfoColumns(olt, oltConfig) {
+		result, err := u.getFromSNMPWithSingleflight(ctx, olt, column.oid+"."+strconv.Itoa(onuInfo.ID)+column.suffix)
+		if err != nil {
+			return err
+		}
+		column.decode(result.Variables[0].Value, onuInfo)
+	}
+	return nil
+}
 
-			// Get Data ONU GPON Optical Distance from SNMP Walk using getOnuGponOpticalDistance method
-			if dist, err := u.getOnuGponOpticalDistance(oltConfig.OnuGponOpticalDistanceOID, strconv.Itoa(onuInfo.ID)); err == nil {
-				onuInfo.GponOpticalDistance = dist
-			}
+func (u *onuUsecase) GetByBoardIDPonIDAndOnuID(ctx context.Context, oltID string, boardID, ponID, onuID int) (
+	model.ONUCustomerInfo, error,
+) {
+	onuInfo, err := u.getONUCustomerInfo(ctx, oltID, boardID, ponID, onuID)
+	if err != nil {
+		return model.ONUCustomerInfo{}, err
+	}
 
-			onuInformationList = onuInfo // Append ONU information to the onuInformationList
+	// The ONU does not exist
+	if onuInfo.ID == 0 {
+		return model.ONUCustomerInfo{}, nil
+	}
+
+	return toONUCustomerInfo(onuInfo), nil
+}
+
+func (u *onuUsecase) GetByBoardIDPonIDAndOnuIDV2(ctx context.Context, oltID string, boardID, ponID, onuID int) (
+	model.ONUCustomerInfoV2, error,
+) {
+	return u.getONUCustomerInfo(ctx, oltID, boardID, ponID, onuID)
+}
+
+// getONUCustomerInfo is a function to get the typed detail of one ONU with a batched SNMP Get,
+// the result is empty when the ONU does not exist
+func (u *onuUsecase) getONUCustomerInfo(ctx context.Context, oltID string, boardID, ponID, onuID int) (
+	model.ONUCustomerInfoV2, error,
+) {
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
+	if err != nil {
+		return model.ONUCustomerInfoV2{}, err
+	}
+
+	// Set key for simple flight
+	key := fmt.Sprintf("onu:%s:%d:%d:%d", olt.Olt.ID, boardID, ponID, onuID)
+
+	// Using simple flight to prevent duplicate SNMP requests
+	result, err := doShared(ctx, &u.sg, key, func(ctx context.Context) (interface{}, error) {
+		oltConfig, err := u.getOltConfig(olt, boardID, ponID) // Get OLT config based on Board ID and PON ID
+		if err != nil {
+			log.Error().Msg("Failed to get OLT Config: " + err.Error())
+			return model.ONUCustomerInfoV2{}, err
+		}
+
+		log.Info().Msg("Get Detail ONU Information with SNMP Get from Board ID: " +
+			strconv.Itoa(boardID) + " PON ID: " + strconv.Itoa(ponID) +
+			" ONU ID: " + strconv.Itoa(onuID))
+
+		// The ONU name is required to tell whether the ONU exists
+		if oltConfig.OnuIDNameOID == "" {
+			return model.ONUCustomerInfoV2{}, errors.New("ONU name OID is not configured")
+		}
+
+		// Collect the OIDs of all ONU attributes, each with the decoder of its ONUCustomerInfoV2 field
+		fields := u.onuDetailFields(olt, oltConfig, strconv.Itoa(onuID))
+		oids := make([]string, len(fields))
+		for i, field := range fields {
+			oids[i] = field.oid
 		}
 
-		return onuInformationList, nil // Return the ONU information list
+		// Get all ONU attributes in one batch, split only when it exceeds the agent's max varbinds
+		variables, err := olt.Snmp.GetBatch(ctx, oids)
+		if err != nil {
+			log.Error().Msg("Failed to perform SNMP Get for ONU detail: " + err.Error())
+			return model.ONUCustomerInfoV2{}, fmt.Errorf("failed to perform SNMP Get: %w", err)
+		}
+
+		// Map each varbind back to its OID, gosnmp returns OIDs with a leading dot
+		snmpDataMap := make(map[string]gosnmp.SnmpPDU, len(variables))
+		for _, pdu := range variables {
+			snmpDataMap[strings.TrimPrefix(pdu.Name, ".")] = pdu
+		}
+
+		// The ONU does not exist when its name is not registered
+		namePDU, ok := snmpDataMap[strings.TrimPrefix(fields[0].oid, ".")]
+		if !ok || !hasValue(namePDU) {
+			return model.ONUCustomerInfoV2{}, nil
+		}
+
+		onuInfo := model.ONUCustomerInfoV2{
+			Board: boardID,
+			PON:   ponID,
+			ID:    onuID,
+		}
+
+		// Decode each varbind into its ONUCustomerInfoV2 field, skipping attributes the agent does not have
+		for _, field := range fields {
+			pdu, ok := snmpDataMap[strings.TrimPrefix(field.oid, ".")]
+			if !ok || !hasValue(pdu) {
+				continue
+			}
+			if err := field.decode(pdu, &onuInfo); err != nil {
+				log.Error().Msg("Failed to decode OID " + field.oid + ": " + err.Error())
+			}
+		}
+
+		// Calculate the traffic rates since the earlier sample of the ONU counters
+		u.setONUTrafficRates(ctx, olt, &onuInfo, time.Now())
+
+		return onuInfo, nil // Return the ONU information
 	})
 
 	if err != nil {
-		return model.ONUCustomerInfo{}, err
+		return model.ONUCustomerInfoV2{}, err
 	}
 
-	return result.(model.ONUCustomerInfo), nil // Return the result from the cache or SNMP Walk
+	// The ONU does not exist
+	onuInfo := result.(model.ONUCustomerInfoV2)
+	if onuInfo.ID == 0 {
+		return onuInfo, nil
+	}
+
+	// Calculate uptime and downtime now, a result shared by singleflight may have been fetched a moment earlier
+	setONUDurations(&onuInfo, time.Now())
+	onuInfo.SignalQuality = classifySignalQuality(olt.Optical, onuInfo.OnuType, onuInfo.RXPower)
+
+	return onuInfo, nil
 }
 
-func (u *onuUsecase) GetEmptyOnuID(ctx context.Context, boardID, ponID int) ([]model.OnuID, error) {
+// setONUDurations sets the uptime since the last online instant and the downtime between the last offline and last
+// online instant, in seconds
+func setONUDurations(info *model.ONUCustomerInfoV2, now time.Time) {
+	if info.LastOnline != nil {
+		uptime := int64(now.Sub(*info.LastOnline) / time.Second)
+		info.UptimeSeconds = &uptime
+	}
+	if info.LastOnline != nil && info.LastOffline != nil {
+		downtime := int64(info.LastOnline.Sub(*info.LastOffline) / time.Second)
+		info.LastDownTimeSeconds = &downtime
+	}
+}
+
+func (u *onuUsecase) GetEmptyOnuID(ctx context.Context, oltID string, boardID, ponID int) ([]model.OnuID, error) {
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set key for simple flight
-	key := fmt.Sprintf("empty_onu_id:%d:%d", boardID, ponID)
+	key := fmt.Sprintf("empty_onu_id:%s:%d:%d", olt.Olt.ID, boardID, ponID)
 
 	// Using simple flight to prevent duplicate requests for the same data
-	result, err, _ := u.sg.Do(key, func() (interface{}, error) {
+	result, err := doShared(ctx, &u.sg, key, func(ctx context.Context) (interface{}, error) {
 		// Get OLT config based on Board ID and PON ID
-		oltConfig, err := u.getOltConfig(boardID, ponID)
+		oltConfig, err := u.getOltConfig(olt, boardID, ponID)
 		if err != nil {
 			log.Error().Msg("Failed to get OLT Config for Get Empty ONU ID: " + err.Error())
 			return nil, err
 		}
 
-		// Redis Key
-		redisKey := "board_" + strconv.Itoa(boardID) + "_pon_" + strconv.Itoa(ponID) + "_empty_onu_id"
+		// Cache key
+		cacheKey := emptyOnuIDCacheKey(olt.Olt.ID, boardID, ponID)
 
-		// Try to get data from Redis using GetOnuIDCtx method with context and Redis key as parameter
-		cachedOnuData, err := u.redisRepository.GetOnuIDCtx(ctx, redisKey)
+		// Try to get data from the cache
+		cachedOnuData, err := u.onuIDCache.Get(ctx, cacheKey)
 		if err == nil && cachedOnuData != nil {
-			log.Info().Msg("Get Empty ONU ID from Redis with Key: " + redisKey)
-			// If data exists in Redis, return data from Redis
+			log.Info().Msg("Get Empty ONU ID from cache with Key: " + cacheKey)
+			// If data exists in the cache, return data from the cache
 			return cachedOnuData, nil
 		}
 
@@ -850,7 +569,7 @@ func (u *onuUsecase) GetEmptyOnuID(ctx context.Context, boardID, ponID int) ([]m
 		log.Info().Msg("Get Empty ONU ID with SNMP Walk from Board ID: " + strconv.Itoa(boardID) + " and PON ID: " + strconv.Itoa(ponID))
 
 		// Perform SNMP Walk to get ONU ID and Name
-		err = u.snmpRepository.Walk(snmpOID, func(pdu gosnmp.SnmpPDU) error {
+		err = olt.Snmp.Walk(ctx, snmpOID, func(pdu gosnmp.SnmpPDU) error {
 			idOnuID := utils.ExtractIDOnuID(pdu.Name)
 			emptyOnuIDList = append(emptyOnuIDList, model.OnuID{
 				Board: boardID,
@@ -874,8 +593,8 @@ func (u *onuUsecase) GetEmptyOnuID(ctx context.Context, boardID, ponID int) ([]m
 		// Remove the numbers that should not be added to the emptyOnuIDList
 		emptyOnuIDList = emptyOnuIDList[:0]
 
-		// Loop through 128 numbers to get the numbers to be deleted
-		for i := 1; i <= 128; i++ {
+		// Loop through the ONU IDs of the PON to get the numbers to be deleted
+		for i := 1; i <= getMaxOnuID(u.cfg); i++ {
 			if _, ok := numbersToRemove[i]; !ok {
 				emptyOnuIDList = append(emptyOnuIDList, model.OnuID{
 					Board: boardID,
@@ -890,14 +609,14 @@ func (u *onuUsecase) GetEmptyOnuID(ctx context.Context, boardID, ponID int) ([]m
 			return emptyOnuIDList[i].ID < emptyOnuIDList[j].ID
 		})
 
-		// Set data to Redis
-		err = u.redisRepository.SetOnuIDCtx(ctx, redisKey, 300, emptyOnuIDList)
+		// Set data to the cache
+		err = u.onuIDCache.Set(ctx, cacheKey, emptyOnuIDList, onuCacheTTL)
 		if err != nil {
-			log.Error().Msg("Failed to set data to Redis: " + err.Error())
+			log.Error().Msg("Failed to set data to cache: " + err.Error())
 			return nil, err
 		}
 
-		log.Info().Msg("Save Empty ONU ID to Redis with Key: " + redisKey)
+		log.Info().Msg("Save Empty ONU ID to cache with Key: " + cacheKey)
 
 		return emptyOnuIDList, nil
 	})
@@ -910,14 +629,20 @@ func (u *onuUsecase) GetEmptyOnuID(ctx context.Context, boardID, ponID int) ([]m
 	return result.([]model.OnuID), nil
 }
 
-func (u *onuUsecase) GetOnuIDAndSerialNumber(boardID, ponID int) ([]model.OnuSerialNumber, error) {
+func (u *onuUsecase) GetOnuIDAndSerialNumber(ctx context.Context, oltID string, boardID, ponID int) ([]model.OnuSerialNumber, error) {
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set key for simple flight
-	key := fmt.Sprintf("onu_id_and_serial_number:%d:%d", boardID, ponID)
+	key := fmt.Sprintf("onu_id_and_serial_number:%s:%d:%d", olt.Olt.ID, boardID, ponID)
 
 	// Using simple flight to prevent duplicate requests for the same data
-	result, err, _ := u.sg.Do(key, func() (interface{}, error) {
+	result, err := doShared(ctx, &u.sg, key, func(ctx context.Context) (interface{}, error) {
 		// Get OLT config based on Board ID and PON ID
-		oltConfig, err := u.getOltConfig(boardID, ponID)
+		oltConfig, err := u.getOltConfig(olt, boardID, ponID)
 		if err != nil {
 			log.Error().Msg("Failed to get OLT Config: " + err.Error())
 			return nil, err
@@ -930,7 +655,7 @@ func (u *onuUsecase) GetOnuIDAndSerialNumber(boardID, ponID int) ([]model.OnuSer
 		log.Info().Msg("Get ONU ID with SNMP Walk from Board ID: " + strconv.Itoa(boardID) + " and PON ID: " + strconv.Itoa(ponID))
 
 		// Perform SNMP BulkWalk to get ONU ID and Name
-		err = u.snmpRepository.Walk(snmpOID, func(pdu gosnmp.SnmpPDU) error {
+		err = olt.Snmp.Walk(ctx, snmpOID, func(pdu gosnmp.SnmpPDU) error {
 			idOnuID := utils.ExtractIDOnuID(pdu.Name)
 			onuIDList = append(onuIDList, model.OnuID{
 				Board: boardID,
@@ -950,7 +675,7 @@ func (u *onuUsecase) GetOnuIDAndSerialNumber(boardID, ponID int) ([]model.OnuSer
 		// Loop through onuIDList to get ONU Serial Number
 		for _, onuInfo := range onuIDList {
 			// Get Data ONU Serial Number from SNMP Walk using getSerialNumber method
-			onuSerialNumber, err := u.getSerialNumber(oltConfig.OnuSerialNumberOID, strconv.Itoa(onuInfo.ID))
+			onuSerialNumber, err := u.getSerialNumber(ctx, olt, oltConfig.OnuSerialNumberOID, strconv.Itoa(onuInfo.ID))
 			if err == nil {
 				onuSerialNumberList = append(onuSerialNumberList, model.OnuSerialNumber{
 					Board:        boardID,
@@ -977,14 +702,20 @@ func (u *onuUsecase) GetOnuIDAndSerialNumber(boardID, ponID int) ([]model.OnuSer
 	return result.([]model.OnuSerialNumber), nil
 }
 
-func (u *onuUsecase) UpdateEmptyOnuID(ctx context.Context, boardID, ponID int) error {
+func (u *onuUsecase) UpdateEmptyOnuID(ctx context.Context, oltID string, boardID, ponID int) error {
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
+	if err != nil {
+		return err
+	}
+
 	// Set key for simple flight
-	key := fmt.Sprintf("update_empty_onu_id:%d:%d", boardID, ponID)
+	key := fmt.Sprintf("update_empty_onu_id:%s:%d:%d", olt.Olt.ID, boardID, ponID)
 
 	// Using simple flight to prevent duplicate requests for the same data
-	_, err, _ := u.sg.Do(key, func() (interface{}, error) {
+	_, err = doShared(ctx, &u.sg, key, func(ctx context.Context) (interface{}, error) {
 		// Get OLT config based on Board ID and PON ID
-		oltConfig, err := u.getOltConfig(boardID, ponID)
+		oltConfig, err := u.getOltConfig(olt, boardID, ponID)
 		if err != nil {
 			log.Error().Msg("Failed to get OLT Config: " + err.Error())
 			return nil, err
@@ -997,7 +728,7 @@ func (u *onuUsecase) UpdateEmptyOnuID(ctx context.Context, boardID, ponID int) e
 		log.Info().Msg("Get Empty ONU ID with SNMP Walk from Board ID: " + strconv.Itoa(boardID) + " and PON ID: " + strconv.Itoa(ponID))
 
 		// Perform SNMP BulkWalk to get ONU ID and Name
-		err = u.snmpRepository.Walk(snmpOID, func(pdu gosnmp.SnmpPDU) error {
+		err = olt.Snmp.Walk(ctx, snmpOID, func(pdu gosnmp.SnmpPDU) error {
 			idOnuID := utils.ExtractIDOnuID(pdu.Name)
 			emptyOnuIDList = append(emptyOnuIDList, model.OnuID{
 				Board: boardID,
@@ -1007,7 +738,7 @@ func (u *onuUsecase) UpdateEmptyOnuID(ctx context.Context, boardID, ponID int) e
 			return nil
 		})
 		if err != nil {
-			return nil, errors.New("failed to perform SNMP Walk")
+			return nil, fmt.Errorf("failed to perform SNMP Walk: %w", err)
 		}
 
 		// Create a map to store numbers to be deleted
@@ -1018,7 +749,7 @@ func (u *onuUsecase) UpdateEmptyOnuID(ctx context.Context, boardID, ponID int) e
 
 		// Filter out ONU IDs that are not empty
 		emptyOnuIDList = emptyOnuIDList[:0]
-		for i := 1; i <= 128; i++ {
+		for i := 1; i <= getMaxOnuID(u.cfg); i++ {
 			if _, ok := numbersToRemove[i]; !ok {
 				emptyOnuIDList = append(emptyOnuIDList, model.OnuID{
 					Board: boardID,
@@ -1033,15 +764,15 @@ func (u *onuUsecase) UpdateEmptyOnuID(ctx context.Context, boardID, ponID int) e
 			return emptyOnuIDList[i].ID < emptyOnuIDList[j].ID
 		})
 
-		// Set data to Redis using SetOnuIDCtx method
-		redisKey := "board_" + strconv.Itoa(boardID) + "_pon_" + strconv.Itoa(ponID) + "_empty_onu_id"
-		err = u.redisRepository.SetOnuIDCtx(ctx, redisKey, 300, emptyOnuIDList)
+		// Set data to the cache
+		cacheKey := emptyOnuIDCacheKey(olt.Olt.ID, boardID, ponID)
+		err = u.onuIDCache.Set(ctx, cacheKey, emptyOnuIDList, onuCacheTTL)
 		if err != nil {
-			log.Error().Msg("Failed to set data to Redis: " + err.Error())
-			return nil, errors.New("failed to set data to Redis")
+			log.Error().Msg("Failed to set data to cache: " + err.Error())
+			return nil, errors.New("failed to set data to cache")
 		}
 
-		log.Info().Msg("Save Update Empty ONU ID to Redis with Key: " + redisKey)
+		log.Info().Msg("Save Update Empty ONU ID to cache with Key: " + cacheKey)
 		return nil, nil
 	})
 
@@ -1049,16 +780,22 @@ func (u *onuUsecase) UpdateEmptyOnuID(ctx context.Context, boardID, ponID int) e
 }
 
 func (u *onuUsecase) GetByBoardIDAndPonIDWithPagination(
-	boardID, ponID, pageIndex, pageSize int,
-) ([]model.ONUInfoPerBoard, int) {
+	ctx context.Context, oltID string, boardID, ponID, pageIndex, pageSize int,
+) ([]model.ONUInfoPerBoard, int, error) {
+
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	// Create a unique key for this request based on the parameters
-	key := fmt.Sprintf("get_onu_info:%d:%d:%d:%d", boardID, ponID, pageIndex, pageSize)
+	key := fmt.Sprintf("get_onu_info:%s:%d:%d:%d:%d", olt.Olt.ID, boardID, ponID, pageIndex, pageSize)
 
 	// Using simple flight to prevent duplicate requests for the same data
-	result, err, _ := u.sg.Do(key, func() (interface{}, error) {
+	result, err := doShared(ctx, &u.sg, key, func(ctx context.Context) (interface{}, error) {
 		// Get OLT config based on Board ID and PON ID
-		oltConfig, err := u.getOltConfig(boardID, ponID)
+		oltConfig, err := u.getOltConfig(olt, boardID, ponID)
 		if err != nil {
 			return nil, err
 		}
@@ -1066,12 +803,16 @@ func (u *onuUsecase) GetByBoardIDAndPonIDWithPagination(
 		// SNMP OID variable
 		snmpOID := oltConfig.BaseOID + oltConfig.OnuIDNameOID
 
-		var onlyOnuIDList []model.OnuOnlyID
 		var count int
 
-		// If data does not exist in Redis, then get data from SNMP
-		if len(onlyOnuIDList) == 0 {
-			err := u.snmpRepository.Walk(snmpOID, func(pdu gosnmp.SnmpPDU) error {
+		// Cache key
+		cacheKey := fmt.Sprintf("olt_%s_board_%d_pon_%d_onu_id", olt.Olt.ID, boardID, ponID)
+
+		// If data does not exist in the cache, then get data from SNMP
+		onlyOnuIDList, err := u.onlyOnuIDCache.Get(ctx, cacheKey)
+		if err != nil || len(onlyOnuIDList) == 0 {
+			onlyOnuIDList = nil
+			err := olt.Snmp.Walk(ctx, snmpOID, func(pdu gosnmp.SnmpPDU) error {
 				onlyOnuIDList = append(onlyOnuIDList, model.OnuOnlyID{
 					ID: utils.ExtractIDOnuID(pdu.Name),
 				})
@@ -1081,22 +822,29 @@ func (u *onuUsecase) GetByBoardIDAndPonIDWithPagination(
 			if err != nil {
 				return nil, err
 			}
-		} else {
-			// Optionally, handle Redis case here
-			log.Error().Msg("Failed to get data from Redis")
+
+			if err := u.onlyOnuIDCache.Set(ctx, cacheKey, onlyOnuIDList, onuCacheTTL); err != nil {
+				log.Error().Msg("Failed to save ONU ID to cache: " + err.Error())
+			}
 		}
 
 		// Calculate total count
 		count = len(onlyOnuIDList)
 
-		// Calculate the index of the first item to be retrieved
+		// Calculate the index of the first item to be retrieved, a page past the end is empty
 		startIndex := (pageIndex - 1) * pageSize
+		if startIndex < 0 {
+			startIndex = 0
+		}
+		if startIndex > len(onlyOnuIDList) {
+			startIndex = len(onlyOnuIDList)
+		}
 
 		// Calculate the index of the last item to be retrieved
 		endIndex := startIndex + pageSize
 
 		// If the index of the last item to be retrieved is greater than the number of items, set it to the number of items
-		if endIndex > len(onlyOnuIDList) {
+		if endIndex > len(onlyOnuIDList) || endIndex < startIndex {
 			endIndex = len(onlyOnuIDList)
 		}
 
@@ -1107,44 +855,31 @@ func (u *onuUsecase) GetByBoardIDAndPonIDWithPagination(
 
 		// Loop through onlyOnuIDList to get ONU information based on ONU ID
 		for _, onuID := range onlyOnuIDList {
-			onuInfo := model.ONUInfoPerBoard{
+			// Stop fetching the remaining ONUs when the request is canceled
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			onuInfo := model.ONUInfoPerBoardV2{
 				Board: boardID,  // Set Board ID to ONUInfo struct Board field
 				PON:   ponID,    // Set PON ID to ONUInfo struct PON field
 				ID:    onuID.ID, // Set ONU ID to ONUInfo struct ID field
 			}
 
 			// Get Name based on ONU ID and ONU Name OID and store it to ONU onuInfo struct
-			onuName, err := u.getName(oltConfig.OnuIDNameOID, strconv.Itoa(onuInfo.ID))
+			onuName, err := u.getName(ctx, olt, oltConfig.OnuIDNameOID, strconv.Itoa(onuInfo.ID))
 			if err == nil {
 				onuInfo.Name = onuName // Set ONU Name to ONU onuInfo struct Name field
 			}
 
-			// Get ONU Type based on ONU ID and ONU Type OID and store it to ONU onuInfo struct
-			onuType, err := u.getONUType(oltConfig.OnuTypeOID, strconv.Itoa(onuInfo.ID))
-			if err == nil {
-				onuInfo.OnuType = onuType // Set ONU Type to ONU onuInfo struct OnuType field
-			}
-
-			// Get ONU Serial Number based on ONU ID and ONU Serial Number OID and store it to ONU onuInfo struct
-			onuSerialNumber, err := u.getSerialNumber(oltConfig.OnuSerialNumberOID, strconv.Itoa(onuInfo.ID))
-			if err == nil {
-				onuInfo.SerialNumber = onuSerialNumber // Set ONU Serial Number to ONU onuInfo struct SerialNumber field
-			}
-
-			// Get ONU RX Power based on ONU ID and ONU RX Power OID and store it to ONU onuInfo struct
-			onuRXPower, err := u.getRxPower(oltConfig.OnuRxPowerOID, strconv.Itoa(onuInfo.ID))
-			if err == nil {
-				onuInfo.RXPower = onuRXPower // Set ONU RX Power to ONU onuInfo struct RXPower field
-			}
-
-			// Get ONU Status based on ONU ID and ONU Status OID and store it to ONU onuInfo struct
-			onuStatus, err := u.getStatus(oltConfig.OnuStatusOID, strconv.Itoa(onuInfo.ID))
-			if err == nil {
-				onuInfo.Status = onuStatus // Set ONU Status to ONU onuInfo struct Status field
+			// Get the ONU type, serial number, RX power of the ONU and of the OLT and status with one SNMP Get each
+			if err := u.getONUInfoColumns(ctx, olt, oltConfig, &onuInfo); err != nil {
+				return nil, err
 			}
+			onuInfo.SignalQuality = classifySignalQuality(olt.Optical, onuInfo.OnuType, onuInfo.RXPower)
 
 			// Append ONU information to the onuInformationList
-			onuInformationList = append(onuInformationList, onuInfo)
+			onuInformationList = append(onuInformationList, toONUInfoPerBoard(onuInfo))
 		}
 
 		// Sort ONU information list based on ONU ID ascending
@@ -1161,188 +896,390 @@ func (u *onuUsecase) GetByBoardIDAndPonIDWithPagination(
 
 	// Handle error if any occurred during simple flight processing
 	if err != nil {
-		return nil, 0
+		log.Error().Msg("Failed to get ONU information with pagination: " + err.Error())
+		return nil, 0, err
 	}
 
 	// Extract the result from the simpleflight result and return it
 	paginationResult := result.(model.PaginationResult)
-	return paginationResult.OnuInformationList, paginationResult.Count
-
+	return paginationResult.OnuInformationList, paginationResult.Count, nil
 }
 
-func (u *onuUsecase) getName(OnuIDNameOID, onuID string) (string, error) {
-	oid := u.cfg.OltCfg.BaseOID1 + OnuIDNameOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
+func (u *onuUsecase) getName(ctx context.Context, olt *repository.OltConnection, OnuIDNameOID, onuID string) (string, error) {
+	oid := getOltOID(u.cfg, olt).BaseOID1 + OnuIDNameOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, olt, oid)
 	if err != nil {
 		return "", err
 	}
 	return utils.ExtractName(result.Variables[0].Value), nil
 }
 
-func (u *onuUsecase) getONUType(OnuTypeOID, onuID string) (string, error) {
-	oid := u.cfg.OltCfg.BaseOID2 + OnuTypeOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
+func (u *onuUsecase) getSerialNumber(ctx context.Context, olt *repository.OltConnection, OnuSerialNumberOID, onuID string) (string, error) {
+	oid := getOltOID(u.cfg, olt).BaseOID1 + OnuSerialNumberOID + "." + onuID
+	result, err := u.getFromSNMPWithSingleflight(ctx, olt, oid)
 	if err != nil {
 		return "", err
 	}
-	return utils.ExtractName(result.Variables[0].Value), nil
+	return utils.ExtractSerialNumber(result.Variables[0].Value), nil
 }
 
-func (u *onuUsecase) getSerialNumber(OnuSerialNumberOID, onuID string) (string, error) {
-	oid := u.cfg.OltCfg.BaseOID1 + OnuSerialNumberOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
-	if err != nil {
-		return "", err
-	}
-	return utils.ExtractSerialNumber(result.Variables[0].Value), nil
+// onuDetailField is an ONU attribute OID with the decoder of its ONUCustomerInfoV2 field
+type onuDetailField struct {
+	oid    string
+	decode func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error
 }
 
-func (u *onuUsecase) getTxPower(OnuTxPowerOID, onuID string) (string, error) {
-	oid := u.cfg.OltCfg.BaseOID2 + OnuTxPowerOID + "." + onuID + ".1"
-	result, err := u.getFromSNMPWithSingleflight(oid)
-	if err != nil {
-		return "", err
+// onuDetailFields returns the OIDs of the configured ONU detail attributes, the ONU name comes first.
+// Timestamps without UTC offset are read in the time zone of the OLT clock.
+func (u *onuUsecase) onuDetailFields(olt *repository.OltConnection, oltConfig *model.OltConfig, onuID string) []onuDetailField {
+	oltOID := getOltOID(u.cfg, olt)
+	baseOID1 := oltOID.BaseOID1
+	baseOID2 := oltOID.BaseOID2
+
+	// oid returns the OID of an ONU attribute, empty when the attribute is not configured
+	oid := func(baseOID, attributeOID, suffix string) string {
+		if attributeOID == "" {
+			return ""
+		}
+		return baseOID + attributeOID + "." + onuID + suffix
 	}
-	power, _ := utils.ConvertAndMultiply(result.Variables[0].Value)
-	return power, nil
-}
 
-func (u *onuUsecase) getRxPower(OnuRxPowerOID, onuID string) (string, error) {
-	oid := u.cfg.OltCfg.BaseOID1 + OnuRxPowerOID + "." + onuID + ".1"
-	result, err := u.getFromSNMPWithSingleflight(oid)
-	if err != nil {
-		return "", err
+	fields := []onuDetailField{
+		{oid(baseOID1, oltConfig.OnuIDNameOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			info.Name = utils.ExtractName(pdu.Value)
+			return nil
+		}},
+		{oid(baseOID2, oltConfig.OnuTypeOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			info.OnuType = utils.ExtractName(pdu.Value)
+			return nil
+		}},
+		{oid(baseOID1, oltConfig.OnuSerialNumberOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			info.SerialNumber = utils.ExtractSerialNumber(pdu.Value)
+			return nil
+		}},
+		{oid(baseOID1, oltConfig.OnuRxPowerOID, ".1"), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			power, err := extractOpticalPower(olt.Optical, pdu.Value)
+			if err != nil {
+				return err
+			}
+			info.RXPower = power
+			return nil
+		}},
+		{oid(baseOID1, oltConfig.OnuOltRxPowerOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			power, err := extractOpticalPower(olt.Optical, pdu.Value)
+			if err != nil {
+				return err
+			}
+			info.OltRXPower = power
+			return nil
+		}},
+		{oid(baseOID2, oltConfig.OnuTxPowerOID, ".1"), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			power, err := extractOpticalPower(olt.Optical, pdu.Value)
+			if err != nil {
+				return err
+			}
+			info.TXPower = power
+			return nil
+		}},
+		{oid(baseOID2, oltConfig.OnuTemperatureOID, ".1"), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			temperature, err := utils.ExtractTransceiverTemperature(pdu.Value)
+			if err != nil {
+				return err
+			}
+			info.Transceiver.Temperature = &temperature
+			return nil
+		}},
+		{oid(baseOID2, oltConfig.OnuVoltageOID, ".1"), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			voltage, err := utils.ExtractTransceiverVoltage(pdu.Value)
+			if err != nil {
+				return err
+			}
+			info.Transceiver.Voltage = &voltage
+			return nil
+		}},
+		{oid(baseOID2, oltConfig.OnuBiasCurrentOID, ".1"), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			biasCurrent, err := utils.ExtractTransceiverBiasCurrent(pdu.Value)
+			if err != nil {
+				return err
+			}
+			info.Transceiver.BiasCurrent = &biasCurrent
+			return nil
+		}},
+		{oid(baseOID1, oltConfig.OnuStatusOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			info.Status = utils.ExtractAndGetStatus(pdu.Value)
+			if code, ok := utils.ExtractInteger(pdu.Value); ok {
+				info.StatusCode = &code
+			}
+			return nil
+		}},
+		{oid(baseOID2, oltConfig.OnuIPAddressOID, ".1"), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			info.IPAddress = utils.ExtractName(pdu.Value)
+			return nil
+		}},
+		{oid(baseOID1, oltConfig.OnuDescriptionOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			info.Description = utils.ExtractName(pdu.Value)
+			return nil
+		}},
+		{oid(baseOID1, oltConfig.OnuLastOnlineOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			value, ok := pdu.Value.([]byte)
+			if !ok {
+				return errors.New("last online is not an octet string")
+			}
+			lastOnline, err := utils.ConvertByteArrayToTime(value, olt.Clock)
+			if err != nil {
+				return err
+			}
+			info.LastOnline = &lastOnline
+			return nil
+		}},
+		{oid(baseOID1, oltConfig.OnuLastOfflineOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			value, ok := pdu.Value.([]byte)
+			if !ok {
+				return errors.New("last offline is not an octet string")
+			}
+			lastOffline, err := utils.ConvertByteArrayToTime(value, olt.Clock)
+			if err != nil {
+				return err
+			}
+			info.LastOffline = &lastOffline
+			return nil
+		}},
+		{oid(baseOID1, oltConfig.OnuLastOfflineReasonOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			info.LastOfflineReason = utils.ExtractLastOfflineReason(pdu.Value)
+			if code, ok := utils.ExtractInteger(pdu.Value); ok {
+				info.LastOfflineReasonCode = &code
+			}
+			return nil
+		}},
+		{oid(baseOID1, oltConfig.OnuGponOpticalDistanceOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			if distance, ok := utils.ExtractInteger(pdu.Value); ok {
+				info.GponOpticalDistance = &distance
+			}
+			return nil
+		}},
+		{oid(baseOID1, oltConfig.OnuUpstreamBytesOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			return decodeCounter(pdu, &info.Traffic.UpstreamBytes, &info.Traffic)
+		}},
+		{oid(baseOID1, oltConfig.OnuDownstreamBytesOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			return decodeCounter(pdu, &info.Traffic.DownstreamBytes, &info.Traffic)
+		}},
+		{oid(baseOID1, oltConfig.OnuUpstreamPacketsOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			return decodeCounter(pdu, &info.Traffic.UpstreamPackets, &info.Traffic)
+		}},
+		{oid(baseOID1, oltConfig.OnuDownstreamPacketsOID, ""), func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			return decodeCounter(pdu, &info.Traffic.DownstreamPackets, &info.Traffic)
+		}},
 	}
-	power, _ := utils.ConvertAndMultiply(result.Variables[0].Value)
-	return power, nil
-}
 
-func (u *onuUsecase) getStatus(OnuStatusOID, onuID string) (string, error) {
-	oid := u.cfg.OltCfg.BaseOID1 + OnuStatusOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
-	if err != nil {
-		return "", err
+	// Skip the attributes that are not configured
+	configured := fields[:0]
+	for _, field := range fields {
+		if field.oid != "" {
+			configured = append(configured, field)
+		}
+	}
+	fields = configured
+
+	// The inventory columns are shared with the PON inventory
+	for _, column := range u.onuInventoryColumns(olt, oltConfig) {
+		set := column.set
+		fields = append(fields, onuDetailField{column.oid + "." + onuID, func(pdu gosnmp.SnmpPDU, info *model.ONUCustomerInfoV2) error {
+			set(pdu.Value, &info.Inventory)
+			return nil
+		}})
 	}
-	return utils.ExtractAndGetStatus(result.Variables[0].Value), nil
+
+	return fields
 }
 
-func (u *onuUsecase) getIPAddress(OnuIPAddressOID, onuID string) (string, error) {
-	oid := u.cfg.OltCfg.BaseOID2 + OnuIPAddressOID + "." + onuID + ".1"
-	result, err := u.getFromSNMPWithSingleflight(oid)
-	if err != nil {
-		return "", err
+// decodeCounter decodes a counter varbind into the field of the ONU traffic, marking the traffic as Counter32
+func decodeCounter(pdu gosnmp.SnmpPDU, field **uint64, traffic *model.ONUTrafficV2) error {
+	counter, ok := utils.ExtractCounter(pdu.Value)
+	if !ok {
+		return errors.New("value is not a counter")
 	}
-	return utils.ExtractName(result.Variables[0].Value), nil
+	*field = &counter
+	if pdu.Type == gosnmp.Counter32 {
+		traffic.Counter32 = true
+	}
+	return nil
 }
 
-func (u *onuUsecase) getDescription(OnuDescriptionOID, onuID string) (string, error) {
-	oid := u.cfg.OltCfg.BaseOID1 + OnuDescriptionOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
-	if err != nil {
-		return "", err
+// toONUInfoPerBoard converts the typed ONU information to its string representation
+func toONUInfoPerBoard(info model.ONUInfoPerBoardV2) model.ONUInfoPerBoard {
+	return model.ONUInfoPerBoard{
+		Board:         info.Board,
+		PON:           info.PON,
+		ID:            info.ID,
+		Name:          info.Name,
+		OnuType:       info.OnuType,
+		SerialNumber:  info.SerialNumber,
+		RXPower:       formatOpticalPower(info.RXPower),
+		OltRXPower:    formatOpticalPower(info.OltRXPower),
+		SignalQuality: info.SignalQuality,
+		Status:        info.Status,
 	}
-	return utils.ExtractName(result.Variables[0].Value), nil
 }
 
-func (u *onuUsecase) getLastOnline(OnuLastOnlineOID, onuID string) (string, error) {
-	oid := u.cfg.OltCfg.BaseOID1 + OnuLastOnlineOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
-	if err != nil {
-		return "", err
+// toONUCustomerInfo converts the typed ONU detail to its string representation
+func toONUCustomerInfo(info model.ONUCustomerInfoV2) model.ONUCustomerInfo {
+	result := model.ONUCustomerInfo{
+		Board:             info.Board,
+		PON:               info.PON,
+		ID:                info.ID,
+		Name:              info.Name,
+		Description:       info.Description,
+		OnuType:           info.OnuType,
+		SerialNumber:      info.SerialNumber,
+		RXPower:           formatOpticalPower(info.RXPower),
+		OltRXPower:        formatOpticalPower(info.OltRXPower),
+		SignalQuality:     info.SignalQuality,
+		TXPower:           formatOpticalPower(info.TXPower),
+		Status:            info.Status,
+		IPAddress:         info.IPAddress,
+		LastOnline:        formatDateTime(info.LastOnline),
+		LastOffline:       formatDateTime(info.LastOffline),
+		LastOfflineReason: info.LastOfflineReason,
+		Transceiver: model.ONUTransceiver{
+			Temperature: formatFloat(info.Transceiver.Temperature, 2),
+			Voltage:     formatFloat(info.Transceiver.Voltage, 2),
+			BiasCurrent: formatFloat(info.Transceiver.BiasCurrent, 3),
+		},
+		Traffic: model.ONUTraffic{
+			UpstreamBytes:     formatCounter(info.Traffic.UpstreamBytes),
+			DownstreamBytes:   formatCounter(info.Traffic.DownstreamBytes),
+			UpstreamPackets:   formatCounter(info.Traffic.UpstreamPackets),
+			DownstreamPackets: formatCounter(info.Traffic.DownstreamPackets),
+			UpstreamRate:      formatFloat(info.Traffic.UpstreamBps, 0),
+			DownstreamRate:    formatFloat(info.Traffic.DownstreamBps, 0),
+			Interval:          formatFloat(info.Traffic.IntervalSeconds, 0),
+		},
+		Inventory: info.Inventory,
+	}
+	// The v1 response keeps "Unknown" for a missing distance, like ExtractGponOpticalDistance
+	result.GponOpticalDistance = "Unknown"
+	if info.GponOpticalDistance != nil {
+		result.GponOpticalDistance = strconv.Itoa(*info.GponOpticalDistance)
+	}
+	if info.UptimeSeconds != nil {
+		result.Uptime = utils.ConvertDurationToString(time.Duration(*info.UptimeSeconds) * time.Second)
+	}
+	if info.LastDownTimeSeconds != nil {
+		result.LastDownTimeDuration = utils.ConvertDurationToString(time.Duration(*info.LastDownTimeSeconds) * time.Second)
 	}
+	return result
+}
 
-	value := result.Variables[0].Value.([]byte)
-	return utils.ConvertByteArrayToDateTime(value)
+// formatOpticalPower formats an optical power in dBm with two decimals, empty when it is not available
+func formatOpticalPower(power *float64) string {
+	return formatFloat(power, 2)
 }
 
-func (u *onuUsecase) getLastOffline(OnuLastOfflineOID, onuID string) (string, error) {
-	baseOID := u.cfg.OltCfg.BaseOID1
-	oid := baseOID + OnuLastOfflineOID + "." + onuID
-	oids := []string{oid}
+// formatFloat formats a value with the given decimals, empty when it is not available
+func formatFloat(value *float64, decimals int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', decimals, 64)
+}
 
-	result, err, _ := u.sg.Do(oid, func() (interface{}, error) {
-		return u.snmpRepository.Get(oids)
-	})
-	if err != nil {
-		log.Error().Msg("Failed to perform SNMP Get for last offline: " + err.Error())
-		return "", errors.New("failed to perform SNMP Get")
+// formatCounter formats a counter, empty when it is not available
+func formatCounter(value *uint64) string {
+	if value == nil {
+		return ""
 	}
+	return strconv.FormatUint(*value, 10)
+}
 
-	resultData := result.(*gosnmp.SnmpPacket)
-	if len(resultData.Variables) > 0 {
-		value := resultData.Variables[0].Value.([]byte)
-		return utils.ConvertByteArrayToDateTime(value)
+// formatDateTime formats a time as "2006-01-02 15:04:05", empty when it is not available
+func formatDateTime(t *time.Time) string {
+	if t == nil {
+		return ""
 	}
+	return t.Format("2006-01-02 15:04:05")
+}
 
-	log.Error().Msg("Failed to get ONU Last Offline: No variables in the response")
-	return "", errors.New("no variables in the response")
+// hasValue checks if a varbind holds a value, agents answer missing instances with noSuchObject or noSuchInstance
+func hasValue(pdu gosnmp.SnmpPDU) bool {
+	switch pdu.Type {
+	case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView, gosnmp.Null:
+		return false
+	}
+	return true
 }
 
-func (u *onuUsecase) getLastOfflineReason(OnuLastOfflineReasonOID, onuID string) (string, error) {
-	oid := u.cfg.OltCfg.BaseOID1 + OnuLastOfflineReasonOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
+func (u *onuUsecase) getFromSNMPWithSingleflight(ctx context.Context, olt *repository.OltConnection, oid string) (*gosnmp.SnmpPacket, error) {
+	result, err := doShared(ctx, &u.sg, olt.Olt.ID+":"+oid, func(ctx context.Context) (interface{}, error) {
+		return olt.Snmp.Get(ctx, []string{oid})
+	})
 	if err != nil {
-		return "", err
+		log.Error().Msg("Failed to perform SNMP Get for OID " + oid + ": " + err.Error())
+		return nil, fmt.Errorf("failed to perform SNMP Get: %w", err)
 	}
 
-	return utils.ExtractLastOfflineReason(result.Variables[0].Value), nil
+	packet := result.(*gosnmp.SnmpPacket)
+	if len(packet.Variables) == 0 {
+		log.Error().Msg("No variables returned for OID " + oid)
+		return nil, errors.New("no variables in the response")
+	}
+
+	return packet, nil
 }
 
-func (u *onuUsecase) getOnuGponOpticalDistance(OnuGponOpticalDistanceOID, onuID string) (string, error) {
-	oid := u.cfg.OltCfg.BaseOID1 + OnuGponOpticalDistanceOID + "." + onuID
-	result, err := u.getFromSNMPWithSingleflight(oid)
+func (u *onuUsecase) GetUnactivatedONU(ctx context.Context, oltID string) ([]model.ONUItem, error) {
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return utils.ExtractGponOpticalDistance(result.Variables[0].Value), nil
-}
-
-func (u *onuUsecase) getUptimeDuration(lastOnline string) (string, error) {
-	currentTime := time.Now()
+	// Do not start a telnet session for a canceled request
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
-	lastOnlineTime, err := time.Parse("2006-01-02 15:04:05", lastOnline)
+	// Get unactivated ONU list from OLT CLI
+	output, err := olt.Telnet.Run("show pon onu u")
 	if err != nil {
-		log.Error().Msg("Failed to parse last online time: " + err.Error())
-		return "", err
+		log.Error().Msg("Failed to execute Telnet command: " + err.Error())
+		return nil, err
 	}
 
-	duration := currentTime.Sub(lastOnlineTime) + time.Hour*7
-	return utils.ConvertDurationToString(duration), nil
+	return utils.ParseONULineOutput(output), nil
 }
 
-// Last Down Duration
-func (u *onuUsecase) getLastDownDuration(lastOffline, lastOnline string) (string, error) {
-	lastOfflineTime, err := time.Parse("2006-01-02 15:04:05", lastOffline)
+func (u *onuUsecase) ActivateONU(ctx context.Context, oltID string, slot, port int, request model.ActivateONURequest) (int, string, error) {
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
 	if err != nil {
-		log.Error().Msg("Failed to parse last offline time: " + err.Error())
-		return "", err
+		return 0, "", err
 	}
 
-	lastOnlineTime, err := time.Parse("2006-01-02 15:04:05", lastOnline)
-	if err != nil {
-		log.Error().Msg("Failed to parse last online time: " + err.Error())
-		return "", err
+	// Do not start a telnet session for a canceled request
+	if err := ctx.Err(); err != nil {
+		return 0, "", err
 	}
 
-	duration := lastOnlineTime.Sub(lastOfflineTime)
-	return utils.ConvertDurationToString(duration), nil
-}
-
-func (u *onuUsecase) getFromSNMPWithSingleflight(oid string) (*gosnmp.SnmpPacket, error) {
-	result, err, _ := u.sg.Do(oid, func() (interface{}, error) {
-		return u.snmpRepository.Get([]string{oid})
-	})
-	if err != nil {
-		log.Error().Msg("Failed to perform SNMP Get for OID " + oid + ": " + err.Error())
-		return nil, errors.New("failed to perform SNMP Get")
+	// Get ONU ID from request or from the first available ONU ID
+	var onuID int
+	if request.Onu != nil {
+		onuID = *request.Onu
+	} else {
+		available, err := utils.GetAvailableONUOnly(olt.Telnet.Run, request.OLTIndex, getMaxOnuID(u.cfg))
+		if err != nil || len(available) == 0 {
+			log.Error().Msg("No available ONU found")
+			return 0, "", ErrNoAvailableOnu
+		}
+		onuID = available[0].ID
 	}
 
-	packet := result.(*gosnmp.SnmpPacket)
-	if len(packet.Variables) == 0 {
-		log.Error().Msg("No variables returned for OID " + oid)
-		return nil, errors.New("no variables in the response")
+	// Build and run register command
+	cmd := utils.BuildZTERegisterCommand(slot, port, request.Region, request.SerialNumber, request.Code, onuID)
+	output, err := olt.Telnet.Run(cmd)
+	if err != nil {
+		log.Error().Msg("Activation failed via Telnet: " + err.Error())
+		return onuID, "", err
 	}
 
-	return packet, nil
+	return onuID, output, nil
 }
@@ -0,0 +1,659 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/pkg/cache"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmpsim"
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sync/singleflight"
+)
+
+// cacheStub is a cache.Backend without cached data
+type cacheStub struct{}
+
+func (cacheStub) Get(context.Context, string) ([]byte, error)              { return nil, cache.ErrMiss }
+func (cacheStub) Set(context.Context, string, []byte, time.Duration) error { return nil }
+func (cacheStub) Delete(context.Context, string) error                     { return nil }
+
+// newTestConfig returns the OLT config of config/cfg.yaml
+func newTestConfig(tableFetch bool) *config.Config {
+	return &config.Config{
+		OltCfg: config.OltConfig{
+			TableFetch: tableFetch,
+			OltOIDConfig: config.OltOIDConfig{
+				BaseOID1: ".1.3.6.1.4.1.3902.1082",
+				BaseOID2: ".1.3.6.1.4.1.3902.1012",
+				Rack:     1,
+				Shelf:    1,
+				PonOID: config.PonOIDTemplateCfg{
+					OnuIDNameOID:              ".500.10.2.3.3.1.2.{if_index}",
+					OnuTypeOID:                ".3.50.11.2.1.17.{pon_index}",
+					OnuSerialNumberOID:        ".500.10.2.3.3.1.18.{if_index}",
+					OnuRxPowerOID:             ".500.20.2.2.2.1.10.{if_index}",
+					OnuOltRxPowerOID:          ".500.1.2.4.2.1.2.{if_index}",
+					OnuTxPowerOID:             ".3.50.12.1.1.14.{pon_index}",
+					OnuBiasCurrentOID:         ".3.50.12.1.1.15.{pon_index}",
+					OnuVoltageOID:             ".3.50.12.1.1.16.{pon_index}",
+					OnuTemperatureOID:         ".3.50.12.1.1.17.{pon_index}",
+					OnuStatusOID:              ".500.10.2.3.8.1.4.{if_index}",
+					OnuIPAddressOID:           ".3.50.16.1.1.10.{pon_index}",
+					OnuDescriptionOID:         ".500.10.2.3.3.1.3.{if_index}",
+					OnuLastOnlineOID:          ".500.10.2.3.8.1.5.{if_index}",
+					OnuLastOfflineOID:         ".500.10.2.3.8.1.6.{if_index}",
+					OnuLastOfflineReasonOID:   ".500.10.2.3.8.1.7.{if_index}",
+					OnuGponOpticalDistanceOID: ".500.10.2.3.10.1.2.{if_index}",
+					OnuUpstreamBytesOID:       ".500.10.2.3.20.1.2.{if_index}",
+					OnuDownstreamBytesOID:     ".500.10.2.3.20.1.3.{if_index}",
+					OnuUpstreamPacketsOID:     ".500.10.2.3.20.1.4.{if_index}",
+					OnuDownstreamPacketsOID:   ".500.10.2.3.20.1.5.{if_index}",
+					OnuVendorIDOID:            ".3.50.11.2.1.1.{pon_index}",
+					OnuEquipmentIDOID:         ".3.50.11.2.1.9.{pon_index}",
+					OnuHardwareVersionOID:     ".3.50.11.2.1.2.{pon_index}",
+					OnuActiveSwVersionOID:     ".3.50.11.2.1.3.{pon_index}",
+					OnuStandbySwVersionOID:    ".3.50.11.2.1.4.{pon_index}",
+					OnuMacAddressOID:          ".3.50.11.2.1.8.{pon_index}",
+					OnuEthAdminStateOID:       ".3.50.14.1.1.2.{pon_index}",
+					OnuEthLinkStatusOID:       ".3.50.14.1.1.5.{pon_index}",
+					OnuEthSpeedDuplexOID:      ".3.50.14.1.1.7.{pon_index}",
+				},
+				PortOID: config.PortOIDTemplateCfg{
+					ModuleTypeOID:  ".500.20.1.2.1.2.{if_index}",
+					TxPowerOID:     ".500.20.1.2.1.4.{if_index}",
+					TemperatureOID: ".500.20.1.2.1.6.{if_index}",
+					VoltageOID:     ".500.20.1.2.1.7.{if_index}",
+					BiasCurrentOID: ".500.20.1.2.1.8.{if_index}",
+				},
+				ChassisOID: config.ChassisOIDCfg{
+					CardTypeOID:            ".3.3.1.1.4",
+					CardStatusOID:          ".3.3.1.1.5",
+					CardSoftwareVersionOID: ".3.3.1.1.6",
+					CardCpuUsageOID:        ".3.3.1.1.9",
+					CardMemoryUsageOID:     ".3.3.1.1.11",
+					CardPortCountOID:       ".3.3.1.1.21",
+					FanStatusOID:           ".3.5.1.1.3",
+					FanSpeedOID:            ".3.5.1.1.4",
+					TemperatureNameOID:     ".3.6.1.1.2",
+					TemperatureOID:         ".3.6.1.1.3",
+					PowerStatusOID:         ".3.7.1.1.3",
+				},
+			},
+		},
+	}
+}
+
+// newSimulatorUsecase starts a simulated C320 agent and returns an ONU usecase with it as default OLT
+func newSimulatorUsecase(t *testing.T, tableFetch bool) OnuUseCaseInterface {
+	store, err := snmpsim.LoadFile("../../pkg/snmpsim/testdata/zte-c320.walk")
+	assert.NoError(t, err)
+
+	server, err := snmpsim.Start("127.0.0.1:0", store, "public")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = server.Close() })
+
+	snmpCfg := config.SnmpConfig{Ip: "127.0.0.1", Port: uint16(server.Addr().(*net.UDPAddr).Port), Community: "public"}
+	pool := snmp.NewPool(snmpCfg)
+	t.Cleanup(pool.Close)
+
+	oltRepo := repository.NewOltRepository()
+	oltRepo.Register(model.Olt{ID: repository.DefaultOltID}, repository.OltConnection{
+		Snmp: repository.NewPonRepository(pool),
+	})
+
+	return NewOnuUsecase(oltRepo, cacheStub{}, newTestConfig(tableFetch))
+}
+
+// snmpStub is an SnmpRepositoryInterface failing every request with the error, like an agent behind an open circuit
+// breaker or a full request queue
+type snmpStub struct {
+	err error
+}
+
+func (s snmpStub) Get(context.Context, []string) (*gosnmp.SnmpPacket, error)    { return nil, s.err }
+func (s snmpStub) GetBatch(context.Context, []string) ([]gosnmp.SnmpPDU, error) { return nil, s.err }
+func (s snmpStub) Walk(context.Context, string, func(gosnmp.SnmpPDU) error) error {
+	return s.err
+}
+func (s snmpStub) BulkWalk(context.Context, string, func(gosnmp.SnmpPDU) error) error {
+	return s.err
+}
+
+// columnErrorSnmp is an SnmpRepositoryInterface failing the requests of the OIDs under the prefix with the error, like
+// an agent that stops answering halfway through a table, and passing the other requests on
+type columnErrorSnmp struct {
+	repository.SnmpRepositoryInterface
+	prefix string
+	err    error
+}
+
+func (s columnErrorSnmp) Get(ctx context.Context, oids []string) (*gosnmp.SnmpPacket, error) {
+	for _, oid := range oids {
+		if strings.HasPrefix(oid, s.prefix) {
+			return nil, s.err
+		}
+	}
+	return s.SnmpRepositoryInterface.Get(ctx, oids)
+}
+
+func (s columnErrorSnmp) BulkWalk(ctx context.Context, oid string, walkFunc func(gosnmp.SnmpPDU) error) error {
+	if strings.HasPrefix(oid, s.prefix) {
+		return s.err
+	}
+	return s.SnmpRepositoryInterface.BulkWalk(ctx, oid, walkFunc)
+}
+
+// newReplaySnmp returns an SNMP repository serving the C320 walk fixture without an SNMP agent
+func newReplaySnmp(t *testing.T) repository.SnmpRepositoryInterface {
+	store, err := snmpsim.LoadFile("../../pkg/snmpsim/testdata/zte-c320.walk")
+	assert.NoError(t, err)
+	return repository.NewReplayRepository(store)
+}
+
+// newPatchedReplaySnmp returns an SNMP repository serving the C320 walk fixture without the OIDs under the prefix and
+// with the walk lines added, a line of a recorded OID replaces its value
+func newPatchedReplaySnmp(t *testing.T, prefix string, lines ...string) repository.SnmpRepositoryInterface {
+	fixture, err := os.ReadFile("../../pkg/snmpsim/testdata/zte-c320.walk")
+	assert.NoError(t, err)
+
+	var walk []string
+	for _, line := range strings.Split(string(fixture), "\n") {
+		if prefix == "" || !strings.HasPrefix(line, prefix) {
+			walk = append(walk, line)
+		}
+	}
+	store, err := snmpsim.Load(strings.NewReader(strings.Join(append(walk, lines...), "\n")))
+	assert.NoError(t, err)
+	return repository.NewReplayRepository(store)
+}
+
+// newReplayOltRepository returns an OLT repository with the connection as default OLT, serving the C320 walk fixture
+// without an SNMP agent when the connection has no SNMP repository
+func newReplayOltRepository(t *testing.T, connection repository.OltConnection) repository.OltRepositoryInterface {
+	if connection.Snmp == nil {
+		connection.Snmp = newReplaySnmp(t)
+	}
+
+	oltRepo := repository.NewOltRepository()
+	oltRepo.Register(model.Olt{ID: repository.DefaultOltID}, connection)
+	return oltRepo
+}
+
+// newReplayUsecase returns an ONU usecase serving the C320 walk fixture as default OLT with the given clock time zone,
+// without an SNMP agent
+func newReplayUsecase(t *testing.T, clock *time.Location) OnuUseCaseInterface {
+	return NewOnuUsecase(newReplayOltRepository(t, repository.OltConnection{Clock: clock}), cacheStub{}, newTestConfig(true))
+}
+
+// Columns of the C320 walk fixture patched by the decoding tests, the rows of board 1 PON 1 are indexed by 285278465
+const (
+	serialNumberOID = ".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.18."
+	statusOID       = ".1.3.6.1.4.1.3902.1082.500.10.2.3.8.1.4."
+	rxPowerOID      = ".1.3.6.1.4.1.3902.1082.500.20.2.2.2.1.10."
+)
+
+// recordedOnuIDs returns the ONU IDs of a PON in the C320 walk fixture
+func recordedOnuIDs(port int) []int {
+	var ids []int
+	for id := 1; id <= 2+port%3; id++ {
+		if id == 2 && port%4 == 0 {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestGetByBoardIDAndPonIDWithSimulator(t *testing.T) {
+	for _, tableFetch := range []bool{false, true} {
+		t.Run(fmt.Sprintf("table_fetch=%t", tableFetch), func(t *testing.T) {
+			uc := newSimulatorUsecase(t, tableFetch)
+
+			for board := 1; board <= 2; board++ {
+				for pon := 1; pon <= 16; pon++ {
+					onus, err := uc.GetByBoardIDAndPonID(context.Background(), "", board, pon)
+					assert.NoError(t, err)
+
+					ids := recordedOnuIDs(pon)
+					assert.Len(t, onus, len(ids), "board %d pon %d", board, pon)
+					for i, onu := range onus {
+						assert.Equal(t, ids[i], onu.ID)
+						assert.Equal(t, fmt.Sprintf("ONU-%d-%d-%d", board, pon, onu.ID), onu.Name)
+						assert.Equal(t, fmt.Sprintf("ZTEGC%02X%02X%04X", board, pon, onu.ID), onu.SerialNumber)
+						assert.NotEmpty(t, onu.OnuType)
+						assert.Contains(t, []string{"Online", "Offline"}, onu.Status)
+
+						// The OLT has no reading of an offline ONU
+						if onu.Status == "Online" {
+							assert.NotEmpty(t, onu.RXPower)
+							assert.NotEmpty(t, onu.OltRXPower)
+						} else {
+							assert.Empty(t, onu.RXPower)
+							assert.Empty(t, onu.OltRXPower)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGetByBoardIDPonIDAndOnuIDWithSimulator(t *testing.T) {
+	uc := newSimulatorUsecase(t, true)
+
+	onu, err := uc.GetByBoardIDPonIDAndOnuID(context.Background(), "", 1, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "ONU-1-1-1", onu.Name)
+	assert.Equal(t, "Customer 1/1/1", onu.Description)
+	assert.Equal(t, "F660", onu.OnuType)
+	assert.Equal(t, "ZTEGC01010001", onu.SerialNumber)
+	assert.Equal(t, "-19.25", onu.RXPower)
+	assert.Equal(t, "-20.75", onu.OltRXPower)
+	assert.Equal(t, model.ONUTransceiver{Temperature: "45.25", Voltage: "3.30", BiasCurrent: "12.500"}, onu.Transceiver)
+	assert.Equal(t, "2.10", onu.TXPower)
+	assert.Equal(t, "Online", onu.Status)
+	assert.Equal(t, "10.1.1.1", onu.IPAddress)
+	assert.Equal(t, "2024-05-14 08:01:07", onu.LastOnline)
+	assert.Equal(t, "2024-05-13 22:01:01", onu.LastOffline)
+	assert.Equal(t, "PowerOff", onu.LastOfflineReason)
+	assert.Equal(t, "1248", onu.GponOpticalDistance)
+
+	// An ONU that is not registered has no information
+	onu, err = uc.GetByBoardIDPonIDAndOnuID(context.Background(), "", 2, 16, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ONUCustomerInfo{}, onu)
+
+	_, err = uc.GetByBoardIDPonIDAndOnuID(context.Background(), "olt-9", 1, 1, 1)
+	assert.ErrorIs(t, err, ErrOltNotFound)
+}
+
+func TestGetEmptyOnuIDWithSimulator(t *testing.T) {
+	uc := newSimulatorUsecase(t, true)
+
+	emptyOnuIDs, err := uc.GetEmptyOnuID(context.Background(), "", 2, 4)
+	assert.NoError(t, err)
+	assert.Len(t, emptyOnuIDs, 128-len(recordedOnuIDs(4)))
+	assert.Equal(t, model.OnuID{Board: 2, PON: 4, ID: 2}, emptyOnuIDs[0])
+}
+
+func TestGetByBoardIDAndPonIDWithReplay(t *testing.T) {
+	testCases := []struct {
+		name    string
+		boardID int
+		ponID   int
+		wantIDs []int
+	}{
+		{name: "registered ONUs", boardID: 2, ponID: 16, wantIDs: recordedOnuIDs(16)},
+		{name: "nonexistent board", boardID: 3, ponID: 1},
+		{name: "nonexistent PON", boardID: 1, ponID: 17},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := newReplayUsecase(t, nil)
+
+			onus, err := uc.GetByBoardIDAndPonID(context.Background(), "", tc.boardID, tc.ponID)
+			assert.NoError(t, err)
+			assert.Len(t, onus, len(tc.wantIDs))
+			for i, onu := range onus {
+				assert.Equal(t, tc.wantIDs[i], onu.ID)
+				assert.Equal(t, fmt.Sprintf("ONU-%d-%d-%d", tc.boardID, tc.ponID, onu.ID), onu.Name)
+			}
+		})
+	}
+}
+
+func TestGetByBoardIDAndPonIDFailedColumn(t *testing.T) {
+	for _, tableFetch := range []bool{false, true} {
+		t.Run(fmt.Sprintf("table_fetch=%t", tableFetch), func(t *testing.T) {
+			cfg := newTestConfig(tableFetch)
+			oltConfig, err := (&onuUsecase{cfg: cfg}).getOltConfig(&repository.OltConnection{}, 1, 1)
+			assert.NoError(t, err)
+
+			// The agent stops answering after the ONU names, at the serial number column
+			memory := cache.NewMemory(config.CacheConfig{})
+			oltRepo := newReplayOltRepository(t, repository.OltConnection{Snmp: columnErrorSnmp{
+				SnmpRepositoryInterface: newReplaySnmp(t),
+				prefix:                  cfg.OltCfg.BaseOID1 + oltConfig.OnuSerialNumberOID,
+				err:                     snmp.ErrCircuitOpen,
+			}})
+			uc := NewOnuUsecase(oltRepo, memory, cfg)
+
+			_, err = uc.GetByBoardIDAndPonID(context.Background(), "", 1, 1)
+			assert.ErrorIs(t, err, snmp.ErrCircuitOpen)
+
+			// The incomplete listing is not cached
+			assert.Equal(t, 0, memory.Len())
+		})
+	}
+}
+
+func TestGetByBoardIDAndPonIDOltOID(t *testing.T) {
+	// The shared OIDs locate the boards on shelf 2, the C320 of the walk fixture has them on shelf 1
+	cfg := newTestConfig(true)
+	c320 := cfg.OltCfg.OltOIDConfig
+	cfg.OltCfg.Shelf = 2
+
+	oltRepo := repository.NewOltRepository()
+	oltRepo.Register(model.Olt{ID: "c300"}, repository.OltConnection{Snmp: newReplaySnmp(t)})
+	oltRepo.Register(model.Olt{ID: "c320"}, repository.OltConnection{Snmp: newReplaySnmp(t), OID: &c320})
+	uc := NewOnuUsecase(oltRepo, cacheStub{}, cfg)
+
+	onus, err := uc.GetByBoardIDAndPonID(context.Background(), "c320", 1, 1)
+	assert.NoError(t, err)
+	assert.Len(t, onus, len(recordedOnuIDs(1)))
+
+	onus, err = uc.GetByBoardIDAndPonID(context.Background(), "c300", 1, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, onus)
+}
+
+func TestGetByBoardIDPonIDAndOnuIDWithReplay(t *testing.T) {
+	testCases := []struct {
+		name      string
+		snmp      repository.SnmpRepositoryInterface
+		configure func(cfg *config.Config)
+		boardID   int
+		ponID     int
+		onuID     int
+		wantName  string
+		wantSN    string
+		wantRX    string
+		wantErr   string
+	}{
+		{name: "registered ONU", boardID: 2, ponID: 16, onuID: 3, wantName: "ONU-2-16-3", wantSN: "ZTEGC02100003"},
+		{
+			name:    "missing serial number column",
+			snmp:    newPatchedReplaySnmp(t, serialNumberOID+"285278465."),
+			boardID: 1, ponID: 1, onuID: 1, wantName: "ONU-1-1-1", wantRX: "-19.25",
+		},
+		{
+			name:    "serial number of the wrong type",
+			snmp:    newPatchedReplaySnmp(t, "", serialNumberOID+"285278465.1 = INTEGER: 1"),
+			boardID: 1, ponID: 1, onuID: 1, wantName: "ONU-1-1-1", wantRX: "-19.25",
+		},
+		// 65535 is the RX power of an ONU without a reading
+		{
+			name:    "RX power without reading",
+			snmp:    newPatchedReplaySnmp(t, "", rxPowerOID+"285278465.1.1 = INTEGER: 65535"),
+			boardID: 1, ponID: 1, onuID: 1, wantName: "ONU-1-1-1", wantSN: "ZTEGC01010001",
+		},
+		// An ONU that is not registered has no information
+		{name: "unregistered ONU", boardID: 2, ponID: 16, onuID: 2},
+		{name: "nonexistent board", boardID: 3, ponID: 1, onuID: 1},
+		{
+			name: "without name OID",
+			configure: func(cfg *config.Config) {
+				cfg.OltCfg.PonOID.OnuIDNameOID = ""
+			},
+			boardID: 1, ponID: 1, onuID: 1, wantErr: "ONU name OID is not configured",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig(true)
+			if tc.configure != nil {
+				tc.configure(cfg)
+			}
+			uc := NewOnuUsecase(newReplayOltRepository(t, repository.OltConnection{Snmp: tc.snmp}), cacheStub{}, cfg)
+
+			onu, err := uc.GetByBoardIDPonIDAndOnuID(context.Background(), "", tc.boardID, tc.ponID, tc.onuID)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantName, onu.Name)
+			assert.Equal(t, tc.wantSN, onu.SerialNumber)
+			assert.Equal(t, tc.wantRX, onu.RXPower)
+		})
+	}
+}
+
+func TestGetByBoardIDAndPonIDWithPaginationWithReplay(t *testing.T) {
+	// Board 1 PON 1 has ONU 1, 2 and 3
+	testCases := []struct {
+		name      string
+		page      int
+		pageSize  int
+		wantIDs   []int
+		wantTotal int
+	}{
+		{name: "first page", page: 1, pageSize: 2, wantIDs: []int{1, 2}, wantTotal: 3},
+		{name: "last page", page: 2, pageSize: 2, wantIDs: []int{3}, wantTotal: 3},
+		// A page past the end is empty
+		{name: "page past the end", page: 5, pageSize: 10, wantTotal: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := newReplayUsecase(t, nil)
+
+			onus, total, err := uc.GetByBoardIDAndPonIDWithPagination(context.Background(), "", 1, 1, tc.page, tc.pageSize)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantTotal, total)
+			assert.Len(t, onus, len(tc.wantIDs))
+			for i, onu := range onus {
+				assert.Equal(t, tc.wantIDs[i], onu.ID)
+			}
+		})
+	}
+}
+
+func TestGetByBoardIDPonIDAndOnuIDV2WithSimulator(t *testing.T) {
+	uc := newSimulatorUsecase(t, true)
+
+	onu, err := uc.GetByBoardIDPonIDAndOnuIDV2(context.Background(), "", 1, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "ONU-1-1-1", onu.Name)
+	assert.Equal(t, -19.25, *onu.RXPower)
+	assert.Equal(t, -20.75, *onu.OltRXPower)
+	assert.Equal(t, 2.1, *onu.TXPower)
+	assert.Equal(t, 45.25, *onu.Transceiver.Temperature)
+	assert.Equal(t, 3.3, *onu.Transceiver.Voltage)
+	assert.Equal(t, 12.5, *onu.Transceiver.BiasCurrent)
+	assert.Equal(t, 4, *onu.StatusCode)
+	assert.Equal(t, "Online", onu.Status)
+	assert.Equal(t, time.Date(2024, 5, 14, 8, 1, 7, 0, time.UTC), *onu.LastOnline)
+	assert.Equal(t, time.Date(2024, 5, 13, 22, 1, 1, 0, time.UTC), *onu.LastOffline)
+	assert.Equal(t, 9, *onu.LastOfflineReasonCode)
+	assert.Equal(t, "PowerOff", onu.LastOfflineReason)
+	assert.Equal(t, 1248, *onu.GponOpticalDistance)
+
+	// Timestamps are encoded as RFC3339 and missing values as null
+	onu.TXPower = nil
+	encoded, err := json.Marshal(onu)
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"last_online":"2024-05-14T08:01:07Z"`)
+	assert.Contains(t, string(encoded), `"tx_power_dbm":null`)
+}
+
+func TestGetByBoardIDAndPonIDV2WithSimulator(t *testing.T) {
+	uc := newSimulatorUsecase(t, false)
+
+	onus, err := uc.GetByBoardIDAndPonIDV2(context.Background(), "", 1, 1)
+	assert.NoError(t, err)
+	assert.Len(t, onus, len(recordedOnuIDs(1)))
+	assert.Equal(t, -19.25, *onus[0].RXPower)
+	assert.Equal(t, -20.75, *onus[0].OltRXPower)
+	assert.Equal(t, 4, *onus[0].StatusCode)
+}
+
+func TestGetByBoardIDPonIDAndOnuIDV2WithClock(t *testing.T) {
+	// The recorded timestamps have no UTC offset, they are read in the time zone of the OLT clock
+	uc := newReplayUsecase(t, time.FixedZone("WIB", 7*3600))
+
+	onu, err := uc.GetByBoardIDPonIDAndOnuIDV2(context.Background(), "", 1, 1, 1)
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2024, 5, 14, 1, 1, 7, 0, time.UTC).Equal(*onu.LastOnline))
+	assert.Equal(t, int64(10*3600+6), *onu.LastDownTimeSeconds)
+	assert.InDelta(t, time.Since(*onu.LastOnline).Seconds(), *onu.UptimeSeconds, 2)
+
+	encoded, err := json.Marshal(onu)
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"last_online":"2024-05-14T08:01:07+07:00"`)
+}
+
+func TestGetByBoardIDAndPonIDV2Decoding(t *testing.T) {
+	// ONU 1 of board 1 PON 1 is online with an RX power of -19.25 dBm
+	testCases := []struct {
+		name           string
+		snmp           repository.SnmpRepositoryInterface
+		wantSN         string
+		wantRX         *float64
+		wantStatus     string
+		wantStatusCode *int
+	}{
+		{
+			name:   "all columns",
+			wantSN: "ZTEGC01010001", wantRX: ptr(-19.25), wantStatus: "Online", wantStatusCode: ptr(4),
+		},
+		{
+			name:   "missing serial number column",
+			snmp:   newPatchedReplaySnmp(t, serialNumberOID+"285278465."),
+			wantRX: ptr(-19.25), wantStatus: "Online", wantStatusCode: ptr(4),
+		},
+		// 65535 is the RX power of an ONU without a reading
+		{
+			name:   "RX power without reading",
+			snmp:   newPatchedReplaySnmp(t, "", rxPowerOID+"285278465.1.1 = INTEGER: 65535"),
+			wantSN: "ZTEGC01010001", wantStatus: "Online", wantStatusCode: ptr(4),
+		},
+		{
+			name:   "status of the wrong type",
+			snmp:   newPatchedReplaySnmp(t, "", statusOID+`285278465.1 = STRING: "4"`),
+			wantSN: "ZTEGC01010001", wantRX: ptr(-19.25), wantStatus: "Unknown",
+		},
+	}
+
+	for _, tableFetch := range []bool{false, true} {
+		for _, tc := range testCases {
+			t.Run(fmt.Sprintf("table_fetch=%t/%s", tableFetch, tc.name), func(t *testing.T) {
+				oltRepo := newReplayOltRepository(t, repository.OltConnection{Snmp: tc.snmp})
+				uc := NewOnuUsecase(oltRepo, cacheStub{}, newTestConfig(tableFetch))
+
+				onus, err := uc.GetByBoardIDAndPonIDV2(context.Background(), "", 1, 1)
+				assert.NoError(t, err)
+				assert.Len(t, onus, len(recordedOnuIDs(1)))
+
+				onu := onus[0]
+				assert.Equal(t, 1, onu.ID)
+				assert.Equal(t, tc.wantSN, onu.SerialNumber)
+				assert.Equal(t, tc.wantRX, onu.RXPower)
+				assert.Equal(t, tc.wantStatus, onu.Status)
+				assert.Equal(t, tc.wantStatusCode, onu.StatusCode)
+			})
+		}
+	}
+}
+
+func TestGetByBoardIDAndPonIDV2WithoutOltRxPowerOID(t *testing.T) {
+	for _, tableFetch := range []bool{false, true} {
+		t.Run(fmt.Sprintf("table_fetch=%t", tableFetch), func(t *testing.T) {
+			// Config files without the OLT RX power OID must not walk or Get the base OID
+			cfg := newTestConfig(tableFetch)
+			cfg.OltCfg.PonOID.OnuOltRxPowerOID = ""
+			u := &onuUsecase{cfg: cfg}
+			olt := &repository.OltConnection{}
+			oltConfig, err := u.getOltConfig(olt, 1, 1)
+			assert.NoError(t, err)
+			for _, column := range u.onuInfoColumns(olt, oltConfig) {
+				assert.NotEqual(t, cfg.OltCfg.BaseOID1, column.oid)
+			}
+
+			uc := NewOnuUsecase(newReplayOltRepository(t, repository.OltConnection{}), cacheStub{}, cfg)
+			onus, err := uc.GetByBoardIDAndPonIDV2(context.Background(), "", 1, 1)
+			assert.NoError(t, err)
+			assert.Len(t, onus, len(recordedOnuIDs(1)))
+			for _, onu := range onus {
+				assert.Nil(t, onu.OltRXPower)
+			}
+			assert.NotNil(t, onus[0].RXPower)
+		})
+	}
+}
+
+func TestOnuDetailFieldsSkipsUnconfiguredOIDs(t *testing.T) {
+	cfg := newTestConfig(true)
+	u := &onuUsecase{cfg: cfg}
+
+	olt := &repository.OltConnection{}
+	oltConfig, err := u.getOltConfig(olt, 1, 1)
+	assert.NoError(t, err)
+	configured := u.onuDetailFields(olt, oltConfig, "1")
+
+	// Config files without the ONU counter OIDs must not build varbinds of the base OID
+	cfg.OltCfg.PonOID.OnuUpstreamBytesOID = ""
+	cfg.OltCfg.PonOID.OnuDownstreamBytesOID = ""
+	cfg.OltCfg.PonOID.OnuUpstreamPacketsOID = ""
+	cfg.OltCfg.PonOID.OnuDownstreamPacketsOID = ""
+	oltConfig, err = u.getOltConfig(olt, 1, 1)
+	assert.NoError(t, err)
+	fields := u.onuDetailFields(olt, oltConfig, "1")
+
+	assert.Len(t, fields, len(configured)-4)
+	assert.Equal(t, cfg.OltCfg.BaseOID1+oltConfig.OnuIDNameOID+".1", fields[0].oid)
+	for _, field := range fields {
+		assert.NotEqual(t, cfg.OltCfg.BaseOID1+".1", field.oid)
+	}
+}
+
+func TestSetONUDurations(t *testing.T) {
+	lastOffline := time.Date(2024, 5, 13, 22, 1, 1, 0, time.UTC)
+	lastOnline := time.Date(2024, 5, 14, 8, 1, 7, 0, time.FixedZone("", 7*3600))
+	now := time.Date(2024, 5, 14, 2, 1, 7, 0, time.UTC)
+
+	info := model.ONUCustomerInfoV2{LastOnline: &lastOnline, LastOffline: &lastOffline}
+	setONUDurations(&info, now)
+	assert.Equal(t, int64(3600), *info.UptimeSeconds)
+	assert.Equal(t, int64(3*3600+6), *info.LastDownTimeSeconds)
+
+	info = model.ONUCustomerInfoV2{LastOffline: &lastOffline}
+	setONUDurations(&info, now)
+	assert.Nil(t, info.UptimeSeconds)
+	assert.Nil(t, info.LastDownTimeSeconds)
+}
+
+func TestToONUCustomerInfoGponOpticalDistance(t *testing.T) {
+	distance := 1248
+
+	// v1 reports a missing distance as "Unknown", v2 as null
+	assert.Equal(t, "Unknown", toONUCustomerInfo(model.ONUCustomerInfoV2{}).GponOpticalDistance)
+	assert.Equal(t, "1248", toONUCustomerInfo(model.ONUCustomerInfoV2{GponOpticalDistance: &distance}).GponOpticalDistance)
+}
+
+func TestDoSharedCallerCanceled(t *testing.T) {
+	var sg singleflight.Group
+	started, release := make(chan struct{}), make(chan struct{})
+	workErr, callerErr := make(chan error, 1), make(chan error, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		_, err := doShared(ctx, &sg, "key", func(ctx context.Context) (interface{}, error) {
+			close(started)
+			<-release
+			workErr <- ctx.Err()
+			return nil, nil
+		})
+		callerErr <- err
+	}()
+	<-started
+
+	// The caller that started the request stops waiting, the request goes on for the other callers
+	cancel()
+	assert.ErrorIs(t, <-callerErr, context.Canceled)
+	close(release)
+	assert.NoError(t, <-workErr)
+}
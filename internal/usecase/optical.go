@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/utils"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultGoodMin     = -25.0 // Default lowest RX power in dBm of a good signal
+	defaultWarningMin  = -27.0 // Default lowest RX power in dBm of a warning signal
+	defaultCriticalMin = -35.0 // Default lowest RX power in dBm of a critical signal, below is no signal
+	defaultNoReading   = 65535 // Default raw optical power the OLT reports without a reading
+)
+
+// opticalThresholds are the thresholds classifying the RX power of one ONU type, in dBm
+type opticalThresholds struct {
+	goodMin     float64
+	warningMin  float64
+	criticalMin float64
+}
+
+// getOpticalThresholds returns the thresholds of the ONU type, falling back to the thresholds of the OLT and the defaults
+func getOpticalThresholds(cfg config.OpticalThresholdConfig, onuType string) opticalThresholds {
+	thresholds := opticalThresholds{
+		goodMin:     floatOrDefault(cfg.GoodMin, defaultGoodMin),
+		warningMin:  floatOrDefault(cfg.WarningMin, defaultWarningMin),
+		criticalMin: floatOrDefault(cfg.CriticalMin, defaultCriticalMin),
+	}
+
+	for _, override := range cfg.OnuTypes {
+		if strings.EqualFold(override.OnuType, onuType) {
+			thresholds.goodMin = floatOrDefault(override.GoodMin, thresholds.goodMin)
+			thresholds.warningMin = floatOrDefault(override.WarningMin, thresholds.warningMin)
+			thresholds.criticalMin = floatOrDefault(override.CriticalMin, thresholds.criticalMin)
+			break
+		}
+	}
+
+	return thresholds
+}
+
+// floatOrDefault returns the value, or the default if it is not set
+func floatOrDefault(value, defaultValue float64) float64 {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// extractOpticalPower converts a raw optical power to dBm, nil when the OLT reports a raw value without a reading
+func extractOpticalPower(cfg config.OpticalThresholdConfig, value interface{}) (*float64, error) {
+	noReading := cfg.NoReading
+	if len(noReading) == 0 {
+		noReading = []int{defaultNoReading}
+	}
+	if raw, ok := value.(int); ok && slices.Contains(noReading, raw) {
+		return nil, nil
+	}
+
+	power, err := utils.ExtractOpticalPower(value)
+	if err != nil {
+		return nil, err
+	}
+	return &power, nil
+}
+
+// classify returns the signal quality class of the RX power
+func (t opticalThresholds) classify(power *float64) string {
+	switch {
+	case power == nil:
+		return model.SignalQualityNoReading
+	case *power >= t.goodMin:
+		return model.SignalQualityGood
+	case *power >= t.warningMin:
+		return model.SignalQualityWarning
+	case *power >= t.criticalMin:
+		return model.SignalQualityCritical
+	default:
+		return model.SignalQualityNoSignal
+	}
+}
+
+// classifySignalQuality returns the signal quality class of the RX power of an ONU of the given type
+func classifySignalQuality(cfg config.OpticalThresholdConfig, onuType string, power *float64) string {
+	return getOpticalThresholds(cfg, onuType).classify(power)
+}
+
+// opticalThreshold is the threshold of an optical health query, a signal quality class or an RX power in dBm
+type opticalThreshold struct {
+	quality string  // good, warning or critical, the lowest RX power of the class per ONU type
+	power   float64 // RX power in dBm when no class is given
+}
+
+// parseOpticalThreshold parses the threshold of an optical health query, below the good class when it is empty
+func parseOpticalThreshold(below string) (opticalThreshold, error) {
+	switch below {
+	case "":
+		return opticalThreshold{quality: model.SignalQualityGood}, nil
+	case model.SignalQualityGood, model.SignalQualityWarning, model.SignalQualityCritical:
+		return opticalThreshold{quality: below}, nil
+	}
+
+	power, err := strconv.ParseFloat(below, 64)
+	if err != nil {
+		return opticalThreshold{}, fmt.Errorf("%w: %s", ErrInvalidThreshold, below)
+	}
+	return opticalThreshold{power: power}, nil
+}
+
+// isBelow checks if a reading of the RX power is below the threshold, an RX power without a reading never is
+func (t opticalThreshold) isBelow(thresholds opticalThresholds, power *float64) bool {
+	if power == nil {
+		return false
+	}
+
+	switch t.quality {
+	case model.SignalQualityGood:
+		return *power < thresholds.goodMin
+	case model.SignalQualityWarning:
+		return *power < thresholds.warningMin
+	case model.SignalQualityCritical:
+		return *power < thresholds.criticalMin
+	}
+	return *power < t.power
+}
+
+func (u *onuUsecase) GetByBoardIDBelowOpticalThreshold(ctx context.Context, oltID string, boardID, ponID int, below string) (
+	[]model.ONUInfoPerBoard, error,
+) {
+	onuInformationList, err := u.getONUInfoBelowOpticalThreshold(ctx, oltID, boardID, ponID, below)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the typed ONU information to its string representation
+	result := make([]model.ONUInfoPerBoard, len(onuInformationList))
+	for i, onuInfo := range onuInformationList {
+		result[i] = toONUInfoPerBoard(onuInfo)
+	}
+	return result, nil
+}
+
+func (u *onuUsecase) GetByBoardIDBelowOpticalThresholdV2(ctx context.Context, oltID string, boardID, ponID int, below string) (
+	[]model.ONUInfoPerBoardV2, error,
+) {
+	return u.getONUInfoBelowOpticalThreshold(ctx, oltID, boardID, ponID, below)
+}
+
+// getONUInfoBelowOpticalThreshold is a function to get the ONUs of a PON, or of all PONs of the board when ponID is 0,
+// with an RX power below the threshold. The ONUs are sorted by RX power, the weakest signal first.
+func (u *onuUsecase) getONUInfoBelowOpticalThreshold(ctx context.Context, oltID string, boardID, ponID int, below string) (
+	[]model.ONUInfoPerBoardV2, error,
+) {
+	threshold, err := parseOpticalThreshold(below)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
+	if err != nil {
+		return nil, err
+	}
+
+	// All PONs of the board when no PON is given
+	ponIDs := []int{ponID}
+	if ponID == 0 {
+		board, ok := findBoard(getTopology(u.cfg, olt), boardID)
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidBoardID, boardID)
+		}
+		ponIDs = make([]int, 0, board.Pons)
+		for id := 1; id <= board.Pons; id++ {
+			ponIDs = append(ponIDs, id)
+		}
+	}
+
+	log.Info().Msg("Get ONU below optical threshold " + below + " from Board ID: " + strconv.Itoa(boardID))
+
+	onuInformationList := make([]model.ONUInfoPerBoardV2, 0)
+	for _, id := range ponIDs {
+		ponOnuList, err := u.getONUInfoList(ctx, oltID, boardID, id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, onuInfo := range ponOnuList {
+			if threshold.isBelow(getOpticalThresholds(olt.Optical, onuInfo.OnuType), onuInfo.RXPower) {
+				onuInformationList = append(onuInformationList, onuInfo)
+			}
+		}
+	}
+
+	// Sort by RX power ascending, then by PON and ONU ID
+	sort.Slice(onuInformationList, func(i, j int) bool {
+		a, b := onuInformationList[i], onuInformationList[j]
+		if *a.RXPower != *b.RXPower {
+			return *a.RXPower < *b.RXPower
+		}
+		if a.PON != b.PON {
+			return a.PON < b.PON
+		}
+		return a.ID < b.ID
+	})
+
+	return onuInformationList, nil
+}
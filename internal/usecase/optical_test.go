@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifySignalQuality(t *testing.T) {
+	cfg := config.OpticalThresholdConfig{
+		GoodMin: -24,
+		OnuTypes: []config.OnuTypeOpticalThresholdConfig{
+			{OnuType: "F660", GoodMin: -26, CriticalMin: -30},
+		},
+	}
+	power := func(dBm float64) *float64 { return &dBm }
+
+	tests := []struct {
+		onuType  string
+		power    *float64
+		expected string
+	}{
+		{"F609", power(-20), model.SignalQualityGood},
+		{"F609", power(-24.5), model.SignalQualityWarning},
+		{"F609", power(-27.5), model.SignalQualityCritical},
+		{"F609", power(-34.9), model.SignalQualityCritical},
+		{"F609", power(-35.1), model.SignalQualityNoSignal},
+		{"f660", power(-24.5), model.SignalQualityGood},
+		{"F660", power(-31), model.SignalQualityNoSignal},
+		{"F609", nil, model.SignalQualityNoReading},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, classifySignalQuality(cfg, tt.onuType, tt.power), "%s %v", tt.onuType, tt.power)
+	}
+}
+
+func TestExtractOpticalPower(t *testing.T) {
+	power := func(dBm float64) *float64 { return &dBm }
+
+	testCases := []struct {
+		name      string
+		noReading []int
+		value     interface{}
+		want      *float64
+		wantErr   bool
+	}{
+		{name: "reading", value: 5375, want: power(-19.25)},
+		{name: "default no reading", value: 65535},
+		{name: "configured no reading", noReading: []int{0}, value: 0},
+		// Only the configured values are sentinels
+		{name: "default not configured", noReading: []int{0}, value: 65535, want: power(101.07)},
+		{name: "not an integer", value: "-19.25", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := extractOpticalPower(config.OpticalThresholdConfig{NoReading: tc.noReading}, tc.value)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseOpticalThreshold(t *testing.T) {
+	threshold, err := parseOpticalThreshold("")
+	assert.NoError(t, err)
+	assert.Equal(t, model.SignalQualityGood, threshold.quality)
+
+	threshold, err = parseOpticalThreshold("-26.5")
+	assert.NoError(t, err)
+	assert.Equal(t, -26.5, threshold.power)
+
+	_, err = parseOpticalThreshold("bad")
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+}
+
+func TestGetByBoardIDBelowOpticalThreshold(t *testing.T) {
+	// The RX power of board 1 PON 1 in the C320 walk fixture is -19.25, -19.75 and no reading
+	oltRepo := newReplayOltRepository(t, repository.OltConnection{
+		Optical: config.OpticalThresholdConfig{GoodMin: -19.5, WarningMin: -20},
+	})
+	uc := NewOnuUsecase(oltRepo, cacheStub{}, newTestConfig(true))
+
+	onus, err := uc.GetByBoardIDAndPonIDV2(context.Background(), "", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, model.SignalQualityGood, onus[0].SignalQuality)
+	assert.Equal(t, model.SignalQualityWarning, onus[1].SignalQuality)
+	assert.Equal(t, model.SignalQualityNoReading, onus[2].SignalQuality)
+	assert.Nil(t, onus[2].RXPower)
+
+	below, err := uc.GetByBoardIDBelowOpticalThresholdV2(context.Background(), "", 1, 1, "good")
+	assert.NoError(t, err)
+	assert.Len(t, below, 1)
+	assert.Equal(t, 2, below[0].ID)
+	assert.Equal(t, -21.25, *below[0].OltRXPower)
+
+	below, err = uc.GetByBoardIDBelowOpticalThresholdV2(context.Background(), "", 1, 1, "warning")
+	assert.NoError(t, err)
+	assert.Empty(t, below)
+
+	// All PONs of the board, the weakest signal first
+	belowBoard, err := uc.GetByBoardIDBelowOpticalThreshold(context.Background(), "", 1, 0, "-19.5")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, belowBoard)
+	assert.Equal(t, "warning", belowBoard[len(belowBoard)-1].SignalQuality)
+	for i := 1; i < len(belowBoard); i++ {
+		previous, _ := strconv.ParseFloat(belowBoard[i-1].RXPower, 64)
+		current, _ := strconv.ParseFloat(belowBoard[i].RXPower, 64)
+		assert.LessOrEqual(t, previous, current)
+	}
+
+}
+
+func TestGetByBoardIDBelowOpticalThresholdErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		boardID int
+		ponID   int
+		below   string
+		wantErr error
+	}{
+		{name: "invalid threshold", boardID: 1, ponID: 1, below: "worse", wantErr: ErrInvalidThreshold},
+		{name: "nonexistent board", boardID: 3, below: "good", wantErr: ErrInvalidBoardID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := newReplayUsecase(t, nil)
+
+			_, err := uc.GetByBoardIDBelowOpticalThreshold(context.Background(), "", tc.boardID, tc.ponID, tc.below)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/internal/utils"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/gosnmp/gosnmp"
+	"github.com/rs/zerolog/log"
+)
+
+// onuStatusOnline is the ONU status code of an online ONU
+const onuStatusOnline = 4
+
+// ponPortField is an OID of a PON port with the decoder of its PonPort field
+type ponPortField struct {
+	oid    string
+	decode func(value interface{}, port *model.PonPort) error
+}
+
+// ponPortFields returns the OIDs of the status and the configured OLT optical module attributes of a PON port
+func (u *onuUsecase) ponPortFields(olt *repository.OltConnection, ifIndex, ponIndex int) []ponPortField {
+	oltOID := getOltOID(u.cfg, olt)
+	baseOID1 := oltOID.BaseOID1
+	tpl := oltOID.PortOID
+	index := "." + strconv.Itoa(ifIndex)
+
+	// oid returns the OID of an optical module attribute, empty when the attribute is not configured
+	oid := func(attributeOID string) string {
+		if attributeOID == "" {
+			return ""
+		}
+		return baseOID1 + utils.BuildPonOID(attributeOID, ifIndex, ponIndex)
+	}
+
+	fields := []ponPortField{
+		{snmp.IfAdminStatusOID + index, func(value interface{}, port *model.PonPort) error {
+			port.AdminStatus = utils.ExtractIfStatus(value)
+			return nil
+		}},
+		{snmp.IfOperStatusOID + index, func(value interface{}, port *model.PonPort) error {
+			port.OperStatus = utils.ExtractIfStatus(value)
+			return nil
+		}},
+		{oid(tpl.ModuleTypeOID), func(value interface{}, port *model.PonPort) error {
+			port.ModuleType = utils.ExtractName(value)
+			return nil
+		}},
+		{oid(tpl.TxPowerOID), func(value interface{}, port *model.PonPort) error {
+			power, err := extractOpticalPower(olt.Optical, value)
+			if err != nil {
+				return err
+			}
+			port.TXPower = power
+			return nil
+		}},
+		{oid(tpl.TemperatureOID), func(value interface{}, port *model.PonPort) error {
+			temperature, err := utils.ExtractTransceiverTemperature(value)
+			if err != nil {
+				return err
+			}
+			port.Temperature = &temperature
+			return nil
+		}},
+		{oid(tpl.VoltageOID), func(value interface{}, port *model.PonPort) error {
+			voltage, err := utils.ExtractTransceiverVoltage(value)
+			if err != nil {
+				return err
+			}
+			port.Voltage = &voltage
+			return nil
+		}},
+		{oid(tpl.BiasCurrentOID), func(value interface{}, port *model.PonPort) error {
+			biasCurrent, err := utils.ExtractTransceiverBiasCurrent(value)
+			if err != nil {
+				return err
+			}
+			port.BiasCurrent = &biasCurrent
+			return nil
+		}},
+	}
+
+	// Skip the attributes that are not configured
+	configured := fields[:0]
+	for _, field := range fields {
+		if field.oid != "" {
+			configured = append(configured, field)
+		}
+	}
+	return configured
+}
+
+func (u *onuUsecase) GetPonPort(ctx context.Context, oltID string, boardID, ponID int) (model.PonPort, error) {
+	if boardID < 1 || ponID < 1 {
+		log.Error().Msg("Invalid Board ID or PON ID")
+		return model.PonPort{}, errors.New("invalid Board ID or PON ID")
+	}
+
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
+	if err != nil {
+		return model.PonPort{}, err
+	}
+
+	// Set key for simple flight
+	key := fmt.Sprintf("pon_port:%s:%d:%d", olt.Olt.ID, boardID, ponID)
+
+	// Using simple flight to prevent duplicate SNMP requests
+	result, err := doShared(ctx, &u.sg, key, func(ctx context.Context) (interface{}, error) {
+		log.Info().Msg("Get PON Port with SNMP Get from Board ID: " + strconv.Itoa(boardID) + " PON ID: " + strconv.Itoa(ponID))
+
+		// The port tables are keyed by the ifIndex of the PON port
+		rack, shelf := u.getRackShelf(olt)
+		ifIndex := utils.GponIfIndex(rack, shelf, boardID, ponID)
+		ponIndex := utils.GponPonIndex(shelf, boardID, ponID)
+
+		port := model.PonPort{
+			Board:   boardID,
+			PON:     ponID,
+			Name:    utils.GponOltName(shelf, boardID, ponID),
+			IfIndex: ifIndex,
+		}
+
+		// Get the status and the optical module of the port in one batch
+		fields := u.ponPortFields(olt, ifIndex, ponIndex)
+		oids := make([]string, len(fields))
+		for i, field := range fields {
+			oids[i] = field.oid
+		}
+
+		variables, err := olt.Snmp.GetBatch(ctx, oids)
+		if err != nil {
+			log.Error().Msg("Failed to perform SNMP Get for PON port: " + err.Error())
+			return model.PonPort{}, fmt.Errorf("failed to perform SNMP Get: %w", err)
+		}
+
+		// Map each varbind back to its OID, gosnmp returns OIDs with a leading dot
+		snmpDataMap := make(map[string]gosnmp.SnmpPDU, len(variables))
+		for _, pdu := range variables {
+			snmpDataMap[strings.TrimPrefix(pdu.Name, ".")] = pdu
+		}
+
+		// Decode each varbind into its PonPort field, skipping attributes the agent does not have
+		for _, field := range fields {
+			pdu, ok := snmpDataMap[strings.TrimPrefix(field.oid, ".")]
+			if !ok || !hasValue(pdu) {
+				continue
+			}
+			if err := field.decode(pdu.Value, &port); err != nil {
+				log.Error().Msg("Failed to decode OID " + field.oid + ": " + err.Error())
+			}
+		}
+
+		// Count the registered ONUs of the port by status
+		onuInformationList, err := u.getONUInfoList(ctx, oltID, boardID, ponID)
+		if err != nil {
+			return model.PonPort{}, err
+		}
+		for _, onuInfo := range onuInformationList {
+			port.RegisteredOnus++
+			if onuInfo.StatusCode != nil && *onuInfo.StatusCode == onuStatusOnline {
+				port.OnlineOnus++
+			}
+		}
+		port.OfflineOnus = port.RegisteredOnus - port.OnlineOnus
+
+		return port, nil
+	})
+
+	if err != nil {
+		log.Error().Msg("Failed to get PON Port: " + err.Error())
+		return model.PonPort{}, err
+	}
+
+	return result.(model.PonPort), nil
+}
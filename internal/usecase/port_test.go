@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPonPortWithReplay(t *testing.T) {
+	testCases := []struct {
+		name           string
+		boardID        int
+		wantIfIndex    int
+		wantOperStatus string
+		wantModuleType string
+		wantRegistered int
+		wantOnline     int
+	}{
+		// ONU 1 and 2 are online, ONU 3 is offline
+		{
+			name: "GPON port", boardID: 1, wantIfIndex: 285278465, wantOperStatus: "up", wantModuleType: "GPON C+ SFP",
+			wantRegistered: 3, wantOnline: 2,
+		},
+		// The port of a nonexistent board has its name and IfIndex only
+		{name: "nonexistent board", boardID: 3, wantIfIndex: 285278977},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := newReplayUsecase(t, nil)
+
+			port, err := uc.GetPonPort(context.Background(), "", tc.boardID, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantIfIndex, port.IfIndex)
+			assert.Equal(t, tc.wantOperStatus, port.OperStatus)
+			assert.Equal(t, tc.wantModuleType, port.ModuleType)
+			assert.Equal(t, tc.wantRegistered, port.RegisteredOnus)
+			assert.Equal(t, tc.wantOnline, port.OnlineOnus)
+			assert.Equal(t, tc.wantRegistered-tc.wantOnline, port.OfflineOnus)
+		})
+	}
+}
+
+func TestGetPonPortOpticalModule(t *testing.T) {
+	const module = ".1.3.6.1.4.1.3902.1082.500.20.1.2.1"
+	power := func(dBm float64) *float64 { return &dBm }
+	temperature := func(celsius float64) *float64 { return &celsius }
+
+	testCases := []struct {
+		name            string
+		snmp            repository.SnmpRepositoryInterface
+		wantTXPower     *float64
+		wantTemperature *float64
+	}{
+		{name: "optical module", wantTXPower: power(5.22), wantTemperature: temperature(40.63)},
+		{
+			name:        "missing temperature column",
+			snmp:        newPatchedReplaySnmp(t, module+".6."),
+			wantTXPower: power(5.22),
+		},
+		{
+			name:            "TX power without reading",
+			snmp:            newPatchedReplaySnmp(t, "", module+".4.285278465 = INTEGER: 65535"),
+			wantTemperature: temperature(40.63),
+		},
+		{
+			// A value of another type is skipped, the other attributes are decoded
+			name:        "temperature of the wrong type",
+			snmp:        newPatchedReplaySnmp(t, "", module+`.6.285278465 = STRING: "40.63"`),
+			wantTXPower: power(5.22),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := NewOnuUsecase(newReplayOltRepository(t, repository.OltConnection{Snmp: tc.snmp}), cacheStub{}, newTestConfig(true))
+
+			port, err := uc.GetPonPort(context.Background(), "", 1, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, "gpon-olt_1/1/1", port.Name)
+			assert.Equal(t, "up", port.AdminStatus)
+			assert.Equal(t, "GPON C+ SFP", port.ModuleType)
+			assert.Equal(t, tc.wantTXPower, port.TXPower)
+			assert.Equal(t, tc.wantTemperature, port.Temperature)
+			assert.Equal(t, 3.3, *port.Voltage)
+			assert.Equal(t, 20.1, *port.BiasCurrent)
+		})
+	}
+}
+
+func TestGetPonPortWithoutPortOID(t *testing.T) {
+	// Config files without the optical module OIDs must not Get the base OID
+	cfg := newTestConfig(true)
+	cfg.OltCfg.PortOID = config.PortOIDTemplateCfg{}
+	u := &onuUsecase{cfg: cfg}
+	assert.Len(t, u.ponPortFields(&repository.OltConnection{}, 285278465, 268501248), 2)
+
+	uc := NewOnuUsecase(newReplayOltRepository(t, repository.OltConnection{}), cacheStub{}, cfg)
+	port, err := uc.GetPonPort(context.Background(), "", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "up", port.AdminStatus)
+	assert.Empty(t, port.ModuleType)
+	assert.Nil(t, port.TXPower)
+}
@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/internal/utils"
+	"github.com/gosnmp/gosnmp"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultDiscoveryInterval = 300 * time.Second // Default time between the discoveries of the card table
+	defaultMaxOnuID          = 128               // Default max ONU ID of a PON
+	defaultFallbackPons      = 16                // Default PONs per fallback board
+)
+
+var (
+	defaultGponCardTypes  = []string{"GTGO", "GTGH", "GTGL", "GFGL", "GFGH", "GFGM"} // Default types of the GPON cards
+	defaultFallbackBoards = []int{1, 2}                                              // Default GPON boards before discovery
+)
+
+// getOltOID returns the OIDs, rack and shelf of an OLT, the ones shared by all OLTs when it has none of its own
+func getOltOID(cfg *config.Config, olt *repository.OltConnection) config.OltOIDConfig {
+	if olt.OID != nil {
+		return *olt.OID
+	}
+	return cfg.OltCfg.OltOIDConfig
+}
+
+// getRackShelf returns the rack and shelf of the boards, 1 when they are not configured
+func getRackShelf(oid config.OltOIDConfig) (rack, shelf int) {
+	rack, shelf = oid.Rack, oid.Shelf
+	if rack == 0 {
+		rack = 1
+	}
+	if shelf == 0 {
+		shelf = 1
+	}
+	return rack, shelf
+}
+
+// getMaxOnuID returns the max ONU ID of a PON
+func getMaxOnuID(cfg *config.Config) int {
+	if cfg.OltCfg.Topology.MaxOnuID <= 0 {
+		return defaultMaxOnuID
+	}
+	return cfg.OltCfg.Topology.MaxOnuID
+}
+
+// getFallbackPons returns the number of PONs of a GPON board without a discovered port count
+func getFallbackPons(cfg *config.Config) int {
+	if cfg.OltCfg.Topology.FallbackPons <= 0 {
+		return defaultFallbackPons
+	}
+	return cfg.OltCfg.Topology.FallbackPons
+}
+
+// getTopology returns the discovered topology of an OLT, or the fallback boards of the configuration until the card
+// table is discovered
+func getTopology(cfg *config.Config, olt *repository.OltConnection) model.OltTopology {
+	if olt.Topology != nil {
+		if topology, ok := olt.Topology.Get(); ok {
+			return topology
+		}
+	}
+
+	boardIDs := cfg.OltCfg.Topology.FallbackBoards
+	if len(boardIDs) == 0 {
+		boardIDs = defaultFallbackBoards
+	}
+
+	topology := model.OltTopology{
+		OltID:    olt.Olt.ID,
+		MaxOnuID: getMaxOnuID(cfg),
+		Boards:   make([]model.OltBoard, 0, len(boardIDs)),
+	}
+	for _, boardID := range boardIDs {
+		topology.Boards = append(topology.Boards, model.OltBoard{
+			Board:  boardID,
+			Status: "unknown",
+			Pons:   getFallbackPons(cfg),
+		})
+	}
+	return topology
+}
+
+// findBoard returns a GPON board of the topology
+func findBoard(topology model.OltTopology, boardID int) (model.OltBoard, bool) {
+	for _, board := range topology.Boards {
+		if board.Board == boardID {
+			return board, true
+		}
+	}
+	return model.OltBoard{}, false
+}
+
+// validateTopology checks that a board, PON and ONU ID exist in the topology, 0 skips the PON or ONU ID
+func validateTopology(topology model.OltTopology, boardID, ponID, onuID int) error {
+	board, ok := findBoard(topology, boardID)
+	if !ok {
+		boardIDs := make([]string, 0, len(topology.Boards))
+		for _, board := range topology.Boards {
+			boardIDs = append(boardIDs, strconv.Itoa(board.Board))
+		}
+		return fmt.Errorf("%w. It must be a GPON board of the OLT: %s", ErrInvalidBoardID, strings.Join(boardIDs, ", "))
+	}
+
+	if ponID != 0 && (ponID < 1 || ponID > board.Pons) {
+		return fmt.Errorf("%w. It must be between 1 and %d", ErrInvalidPonID, board.Pons)
+	}
+
+	if onuID != 0 && (onuID < 1 || onuID > topology.MaxOnuID) {
+		return fmt.Errorf("%w. It must be between 1 and %d", ErrInvalidOnuID, topology.MaxOnuID)
+	}
+
+	return nil
+}
+
+// isGponCardType reports whether a card type has GPON ports
+func isGponCardType(cfg *config.Config, cardType string) bool {
+	cardTypes := cfg.OltCfg.Topology.GponCardTypes
+	if len(cardTypes) == 0 {
+		cardTypes = defaultGponCardTypes
+	}
+
+	for _, gponCardType := range cardTypes {
+		if strings.EqualFold(cardType, gponCardType) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTopology is a function to check that a board, PON and ONU ID exist on an OLT, 0 skips the PON or ONU ID
+func (u *onuUsecase) ValidateTopology(oltID string, boardID, ponID, onuID int) error {
+	olt, err := u.getOlt(oltID)
+	if err != nil {
+		return err
+	}
+
+	return validateTopology(getTopology(u.cfg, olt), boardID, ponID, onuID)
+}
+
+// GetTopology is a function to get the GPON boards and PONs of an OLT
+func (u *oltUsecase) GetTopology(oltID string) (model.OltTopology, error) {
+	olt, ok := u.oltRepository.Get(oltID)
+	if !ok {
+		return model.OltTopology{}, ErrOltNotFound
+	}
+
+	return getTopology(u.cfg, olt), nil
+}
+
+// DiscoverTopology is a function to discover the GPON boards and PONs of an OLT from the card table. The discovered
+// topology replaces the previous one, it is kept when the card table has no GPON board.
+func (u *oltUsecase) DiscoverTopology(ctx context.Context, oltID string) (model.OltTopology, error) {
+	olt, ok := u.oltRepository.Get(oltID)
+	if !ok {
+		return model.OltTopology{}, ErrOltNotFound
+	}
+
+	// Set key for simple flight
+	key := "olt_topology:" + olt.Olt.ID
+
+	// Using simple flight to prevent duplicate SNMP requests
+	result, err := doShared(ctx, &u.sg, key, func(ctx context.Context) (interface{}, error) {
+		log.Info().Msg("Discover GPON boards with SNMP BulkWalk from OLT: " + olt.Olt.ID)
+
+		oid := getOltOID(u.cfg, olt)
+		baseOID2 := oid.BaseOID2
+		chassisOID := oid.ChassisOID
+		rack, shelf := getRackShelf(oid)
+
+		// The card types are required, the other columns are optional
+		if chassisOID.CardTypeOID == "" {
+			return model.OltTopology{}, errors.New("card type OID is not configured")
+		}
+
+		// Cards of the rack and shelf joined by slot
+		boards := make(map[int]*model.OltBoard)
+		portCounts := make(map[int]int)
+		columns := []chassisColumn{
+			{baseOID2 + chassisOID.CardTypeOID, func(index []int, value interface{}) {
+				boards[index[2]] = &model.OltBoard{Board: index[2], Type: utils.ExtractName(value), Status: "unknown"}
+			}},
+			{baseOID2 + chassisOID.CardStatusOID, func(index []int, value interface{}) {
+				if board, ok := boards[index[2]]; ok {
+					board.Status = utils.ExtractCardStatus(value)
+				}
+			}},
+			{baseOID2 + chassisOID.CardPortCountOID, func(index []int, value interface{}) {
+				if ports, ok := utils.ExtractInteger(value); ok {
+					portCounts[index[2]] = ports
+				}
+			}},
+		}
+
+		for _, column := range columns {
+			// Skip the optional columns that are not configured, their OID is the base OID
+			if column.oid == baseOID2 {
+				continue
+			}
+			err := olt.Snmp.BulkWalk(ctx, column.oid, func(pdu gosnmp.SnmpPDU) error {
+				index, ok := utils.ExtractTableIndex(pdu.Name, column.oid)
+				if !ok || len(index) != 3 || index[0] != rack || index[1] != shelf || !hasValue(pdu) {
+					return nil
+				}
+				column.set(index, pdu.Value)
+				return nil
+			})
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return model.OltTopology{}, ctxErr
+			}
+			if err != nil {
+				// Keep the topology discovered earlier rather than boards without their status or PONs
+				log.Error().Msg("Failed to perform SNMP BulkWalk for OID " + column.oid + ": " + err.Error())
+				return model.OltTopology{}, fmt.Errorf("failed to perform SNMP BulkWalk: %w", err)
+			}
+		}
+
+		now := time.Now()
+		topology := model.OltTopology{
+			OltID:        olt.Olt.ID,
+			Discovered:   true,
+			DiscoveredAt: &now,
+			MaxOnuID:     getMaxOnuID(u.cfg),
+			Boards:       make([]model.OltBoard, 0),
+		}
+		for slot, board := range boards {
+			if !isGponCardType(u.cfg, board.Type) {
+				continue
+			}
+			board.Pons = getFallbackPons(u.cfg)
+			if ports, ok := portCounts[slot]; ok && ports > 0 {
+				board.Pons = ports
+			}
+			topology.Boards = append(topology.Boards, *board)
+		}
+		if len(topology.Boards) == 0 {
+			return model.OltTopology{}, errors.New("no GPON board found in the card table")
+		}
+		sort.Slice(topology.Boards, func(i, j int) bool {
+			return topology.Boards[i].Board < topology.Boards[j].Board
+		})
+
+		olt.Topology.Save(topology)
+		return topology, nil
+	})
+
+	if err != nil {
+		log.Error().Msg("Failed to discover OLT topology: " + err.Error())
+		return model.OltTopology{}, err
+	}
+
+	return result.(model.OltTopology), nil
+}
+
+// WatchTopology is a function to discover the topology of every OLT at startup and then periodically, until the
+// context is done. Each OLT is discovered on its own, so an unreachable OLT does not delay the others.
+func (u *oltUsecase) WatchTopology(ctx context.Context) {
+	interval := defaultDiscoveryInterval
+	if u.cfg.OltCfg.Topology.DiscoveryInterval > 0 {
+		interval = time.Duration(u.cfg.OltCfg.Topology.DiscoveryInterval) * time.Second
+	}
+
+	var wg sync.WaitGroup
+	for _, olt := range u.oltRepository.List() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u.watchOltTopology(ctx, olt.ID, interval)
+		}()
+	}
+	wg.Wait()
+}
+
+// watchOltTopology is a function to discover the topology of one OLT every interval, each discovery is bounded by the
+// interval
+func (u *oltUsecase) watchOltTopology(ctx context.Context, oltID string, interval time.Duration) {
+	// Without the card type column there is nothing to discover, the fallback boards are kept
+	olt, ok := u.oltRepository.Get(oltID)
+	if !ok || getOltOID(u.cfg, olt).ChassisOID.CardTypeOID == "" {
+		log.Info().Str("olt_id", oltID).Msg("Card type OID is not configured, using the fallback GPON boards")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Keep the previous topology, or the fallback boards, when the discovery fails
+		discoverCtx, cancel := context.WithTimeout(ctx, interval)
+		if _, err := u.DiscoverTopology(discoverCtx, oltID); err != nil {
+			log.Error().Str("olt_id", oltID).Msg("Failed to discover GPON boards: " + err.Error())
+		}
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
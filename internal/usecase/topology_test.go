@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscoverTopologyWithReplay(t *testing.T) {
+	testCases := []struct {
+		name       string
+		snmp       repository.SnmpRepositoryInterface
+		configure  func(cfg *config.Config)
+		wantBoards []model.OltBoard
+		wantErr    string
+	}{
+		{
+			// The GPON boards of the card table, without the control cards
+			name: "GPON boards",
+			wantBoards: []model.OltBoard{
+				{Board: 1, Type: "GTGH", Status: "inService", Pons: 16},
+				{Board: 2, Type: "GTGH", Status: "inService", Pons: 16},
+			},
+		},
+		{
+			// Without the port counts the boards have the fallback PONs
+			name: "without port count OID",
+			configure: func(cfg *config.Config) {
+				cfg.OltCfg.ChassisOID.CardPortCountOID = ""
+			},
+			wantBoards: []model.OltBoard{
+				{Board: 1, Type: "GTGH", Status: "inService", Pons: 8},
+				{Board: 2, Type: "GTGH", Status: "inService", Pons: 8},
+			},
+		},
+		{
+			// A board without its port count has the fallback PONs
+			name: "missing port count of a board",
+			snmp: newPatchedReplaySnmp(t, ".1.3.6.1.4.1.3902.1012.3.3.1.1.21.1.1.2 "),
+			wantBoards: []model.OltBoard{
+				{Board: 1, Type: "GTGH", Status: "inService", Pons: 16},
+				{Board: 2, Type: "GTGH", Status: "inService", Pons: 8},
+			},
+		},
+		{
+			// A port count of 0 is not a PON count
+			name: "port count of 0",
+			snmp: newPatchedReplaySnmp(t, "", ".1.3.6.1.4.1.3902.1012.3.3.1.1.21.1.1.1 = INTEGER: 0"),
+			wantBoards: []model.OltBoard{
+				{Board: 1, Type: "GTGH", Status: "inService", Pons: 8},
+				{Board: 2, Type: "GTGH", Status: "inService", Pons: 16},
+			},
+		},
+		{
+			name: "port count of the wrong type",
+			snmp: newPatchedReplaySnmp(t, "", `.1.3.6.1.4.1.3902.1012.3.3.1.1.21.1.1.1 = STRING: "16"`),
+			wantBoards: []model.OltBoard{
+				{Board: 1, Type: "GTGH", Status: "inService", Pons: 8},
+				{Board: 2, Type: "GTGH", Status: "inService", Pons: 16},
+			},
+		},
+		{
+			name: "card status of the wrong type",
+			snmp: newPatchedReplaySnmp(t, "", `.1.3.6.1.4.1.3902.1012.3.3.1.1.5.1.1.2 = STRING: "1"`),
+			wantBoards: []model.OltBoard{
+				{Board: 1, Type: "GTGH", Status: "inService", Pons: 16},
+				{Board: 2, Type: "GTGH", Status: "unknown", Pons: 16},
+			},
+		},
+		{
+			// The card types are required, the base OID is not walked
+			name: "without card type OID",
+			configure: func(cfg *config.Config) {
+				cfg.OltCfg.ChassisOID.CardTypeOID = ""
+			},
+			wantErr: "card type OID is not configured",
+		},
+		{
+			name: "without GPON board",
+			configure: func(cfg *config.Config) {
+				cfg.OltCfg.Topology.GponCardTypes = []string{"GFGM"}
+			},
+			wantErr: "no GPON board found in the card table",
+		},
+		{
+			// Boards without their port counts do not replace the fallback
+			name: "failed port count column",
+			snmp: columnErrorSnmp{
+				SnmpRepositoryInterface: newReplaySnmp(t),
+				prefix:                  ".1.3.6.1.4.1.3902.1012.3.3.1.1.21",
+				err:                     snmp.ErrCircuitOpen,
+			},
+			wantErr: snmp.ErrCircuitOpen.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig(true)
+			cfg.OltCfg.Topology.FallbackPons = 8
+			if tc.configure != nil {
+				tc.configure(cfg)
+			}
+			uc := NewOltUsecase(newReplayOltRepository(t, repository.OltConnection{Snmp: tc.snmp}), cfg)
+
+			discovered, err := uc.DiscoverTopology(context.Background(), "")
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+
+				// The fallback boards are kept
+				if topology, err := uc.GetTopology(""); assert.NoError(t, err) {
+					assert.False(t, topology.Discovered)
+					assert.Len(t, topology.Boards, 2)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, discovered.Discovered)
+			assert.NotNil(t, discovered.DiscoveredAt)
+			assert.Equal(t, 128, discovered.MaxOnuID)
+			assert.Equal(t, tc.wantBoards, discovered.Boards)
+
+			topology, err := uc.GetTopology("")
+			assert.NoError(t, err)
+			assert.Equal(t, discovered, topology)
+		})
+	}
+}
+
+func TestValidateTopologyWithReplay(t *testing.T) {
+	oltRepo := newReplayOltRepository(t, repository.OltConnection{})
+	cfg := newTestConfig(true)
+	cfg.OltCfg.Topology.FallbackBoards = []int{1}
+	cfg.OltCfg.Topology.FallbackPons = 8
+	olt := NewOltUsecase(oltRepo, cfg)
+	onu := NewOnuUsecase(oltRepo, cacheStub{}, cfg)
+
+	// The fallback boards until the card table is discovered
+	topology, err := olt.GetTopology("")
+	assert.NoError(t, err)
+	assert.False(t, topology.Discovered)
+	assert.Nil(t, topology.DiscoveredAt)
+	assert.Equal(t, []model.OltBoard{{Board: 1, Status: "unknown", Pons: 8}}, topology.Boards)
+	assert.ErrorIs(t, onu.ValidateTopology("", 2, 1, 0), ErrInvalidBoardID)
+	assert.ErrorIs(t, onu.ValidateTopology("", 1, 9, 0), ErrInvalidPonID)
+
+	_, err = olt.DiscoverTopology(context.Background(), "")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		boardID int
+		ponID   int
+		onuID   int
+		wantErr string
+	}{
+		{name: "last ONU of the last PON", boardID: 2, ponID: 16, onuID: 128},
+		{name: "board only", boardID: 2},
+		{name: "nonexistent board", boardID: 3, ponID: 1, wantErr: "invalid 'board_id' parameter. It must be a GPON board of the OLT: 1, 2"},
+		{name: "nonexistent PON", boardID: 1, ponID: 17, wantErr: "invalid 'pon_id' parameter. It must be between 1 and 16"},
+		{name: "ONU ID out of range", boardID: 1, ponID: 1, onuID: 129, wantErr: "invalid 'onu_id' parameter. It must be between 1 and 128"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := onu.ValidateTopology("", tc.boardID, tc.ponID, tc.onuID)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// blockingSnmp is an SnmpRepositoryInterface whose walks do not return until ctx is done, like an unreachable agent
+type blockingSnmp struct {
+	snmpStub
+}
+
+func (blockingSnmp) BulkWalk(ctx context.Context, _ string, _ func(gosnmp.SnmpPDU) error) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestWatchTopologyUnreachableOlt(t *testing.T) {
+	// The unreachable OLT is registered first
+	oltRepo := repository.NewOltRepository()
+	oltRepo.Register(model.Olt{ID: "olt-down"}, repository.OltConnection{Snmp: blockingSnmp{}})
+	oltRepo.Register(model.Olt{ID: repository.DefaultOltID}, repository.OltConnection{Snmp: newReplaySnmp(t)})
+	uc := NewOltUsecase(oltRepo, newTestConfig(true))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go uc.WatchTopology(ctx)
+
+	// The reachable OLT is discovered while the discovery of the unreachable OLT is pending
+	assert.Eventually(t, func() bool {
+		topology, err := uc.GetTopology(repository.DefaultOltID)
+		return err == nil && topology.Discovered
+	}, time.Second, time.Millisecond)
+
+	topology, err := uc.GetTopology("olt-down")
+	assert.NoError(t, err)
+	assert.False(t, topology.Discovered)
+}
+
+func TestWatchTopologyWithoutCardTypeOID(t *testing.T) {
+	// Config files without the chassis tables keep the fallback boards without polling the OLT
+	cfg := newTestConfig(true)
+	cfg.OltCfg.ChassisOID = config.ChassisOIDCfg{}
+	uc := NewOltUsecase(newReplayOltRepository(t, repository.OltConnection{Snmp: blockingSnmp{}}), cfg)
+
+	done := make(chan struct{})
+	go func() {
+		uc.WatchTopology(context.Background())
+		close(done)
+	}()
+	assert.Eventually(t, func() bool {
+		select {
+		case <-done:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	topology, err := uc.GetTopology(repository.DefaultOltID)
+	assert.NoError(t, err)
+	assert.False(t, topology.Discovered)
+}
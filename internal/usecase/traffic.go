@@ -0,0 +1,413 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/internal/utils"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/gosnmp/gosnmp"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultTrafficInterval    = 60 * time.Second // Default min time between the interface counter samples of the rates
+	defaultOnuTrafficInterval = 10 * time.Second // Default min time between the ONU counter samples of the rates
+)
+
+// trafficSampleCount is the number of counter samples kept per interface
+const trafficSampleCount = 2
+
+const (
+	counter32Range = 1 << 32   // Number of values of a Counter32, it wraps to 0 after 2^32-1
+	gponMaxBps     = 2488320e3 // GPON downstream line rate, the fastest an ONU counter can increase
+	minFrameBits   = 84 * 8    // Bits of the smallest Ethernet frame with its preamble and inter-frame gap
+)
+
+// Max seconds between two samples of an ONU Counter32 in which it can wrap only once at the GPON line rate
+const (
+	onuOctetWrapSeconds  = counter32Range * 8 / gponMaxBps
+	onuPacketWrapSeconds = counter32Range * minFrameBits / gponMaxBps
+)
+
+// uplinkIfNamePrefixes are the ifName prefixes of the uplink interfaces of the ZTE OLTs
+var uplinkIfNamePrefixes = []string{"gei_", "xgei_", "smartgroup"}
+
+// trafficField is an IF-MIB column with the decoder of its InterfaceTraffic field
+type trafficField struct {
+	oid    string
+	decode func(value uint64, traffic *model.InterfaceTraffic)
+}
+
+// trafficFields are the IF-MIB columns of the interface traffic
+var trafficFields = []trafficField{
+	{snmp.IfHCInOctetsOID, func(value uint64, traffic *model.InterfaceTraffic) { traffic.InOctets = value }},
+	{snmp.IfHCOutOctetsOID, func(value uint64, traffic *model.InterfaceTraffic) { traffic.OutOctets = value }},
+	{snmp.IfInErrorsOID, func(value uint64, traffic *model.InterfaceTraffic) { traffic.InErrors = value }},
+	{snmp.IfOutErrorsOID, func(value uint64, traffic *model.InterfaceTraffic) { traffic.OutErrors = value }},
+	{snmp.IfInDiscardsOID, func(value uint64, traffic *model.InterfaceTraffic) { traffic.InDiscards = value }},
+	{snmp.IfOutDiscardsOID, func(value uint64, traffic *model.InterfaceTraffic) { traffic.OutDiscards = value }},
+	{snmp.IfHighSpeedOID, func(value uint64, traffic *model.InterfaceTraffic) { traffic.SpeedMbps = &value }},
+}
+
+// getTrafficInterval returns the min time between the counter samples of the traffic rates
+func (u *onuUsecase) getTrafficInterval() time.Duration {
+	if u.cfg.OltCfg.TrafficInterval <= 0 {
+		return defaultTrafficInterval
+	}
+	return time.Duration(u.cfg.OltCfg.TrafficInterval) * time.Second
+}
+
+// getOnuTrafficInterval returns the min time between the ONU counter samples of the traffic rates
+func (u *onuUsecase) getOnuTrafficInterval() time.Duration {
+	if u.cfg.OltCfg.OnuTrafficInterval <= 0 {
+		return defaultOnuTrafficInterval
+	}
+	return time.Duration(u.cfg.OltCfg.OnuTrafficInterval) * time.Second
+}
+
+// isUplinkIfName checks if the ifName is the name of an uplink interface
+func isUplinkIfName(name string) bool {
+	for _, prefix := range uplinkIfNamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *onuUsecase) GetTraffic(ctx context.Context, oltID string) ([]model.InterfaceTraffic, error) {
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set key for simple flight
+	key := "traffic:" + olt.Olt.ID
+
+	// Using simple flight to prevent duplicate SNMP requests
+	result, err := doShared(ctx, &u.sg, key, func(ctx context.Context) (interface{}, error) {
+		log.Info().Msg("Get interface traffic with SNMP BulkWalk of ifName")
+
+		// Find the GPON and uplink interfaces by their ifIndex and ifName
+		interfaces := make([]model.InterfaceTraffic, 0)
+		err := olt.Snmp.BulkWalk(ctx, snmp.IfNameOID, func(pdu gosnmp.SnmpPDU) error {
+			ifIndex, ok := utils.ExtractOnuIndex(pdu.Name, snmp.IfNameOID, "")
+			if !ok {
+				return nil
+			}
+
+			if _, shelf, slot, port, err := utils.ParseGponIfIndex(ifIndex); err == nil {
+				interfaces = append(interfaces, model.InterfaceTraffic{
+					Name:    utils.GponOltName(shelf, slot, port),
+					IfIndex: ifIndex,
+					Type:    model.InterfaceTypeGpon,
+					Board:   slot,
+					PON:     port,
+				})
+			} else if name := utils.ExtractName(pdu.Value); isUplinkIfName(name) {
+				interfaces = append(interfaces, model.InterfaceTraffic{
+					Name:    name,
+					IfIndex: ifIndex,
+					Type:    model.InterfaceTypeUplink,
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			log.Error().Msg("Failed to perform SNMP BulkWalk for ifName: " + err.Error())
+			return nil, fmt.Errorf("failed to perform SNMP BulkWalk: %w", err)
+		}
+
+		if err := u.getInterfaceTraffic(ctx, olt, interfaces); err != nil {
+			return nil, err
+		}
+
+		// Uplinks first, then the GPON ports by board and PON
+		sort.Slice(interfaces, func(i, j int) bool {
+			a, b := interfaces[i], interfaces[j]
+			if a.Type != b.Type {
+				return a.Type == model.InterfaceTypeUplink
+			}
+			return a.IfIndex < b.IfIndex
+		})
+
+		return interfaces, nil
+	})
+
+	if err != nil {
+		log.Error().Msg("Failed to get interface traffic: " + err.Error())
+		return nil, err
+	}
+
+	return result.([]model.InterfaceTraffic), nil
+}
+
+func (u *onuUsecase) GetPonTraffic(ctx context.Context, oltID string, boardID, ponID int) (model.InterfaceTraffic, error) {
+	if boardID < 1 || ponID < 1 {
+		log.Error().Msg("Invalid Board ID or PON ID")
+		return model.InterfaceTraffic{}, errors.New("invalid Board ID or PON ID")
+	}
+
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
+	if err != nil {
+		return model.InterfaceTraffic{}, err
+	}
+
+	// Set key for simple flight
+	key := fmt.Sprintf("pon_traffic:%s:%d:%d", olt.Olt.ID, boardID, ponID)
+
+	// Using simple flight to prevent duplicate SNMP requests
+	result, err := doShared(ctx, &u.sg, key, func(ctx context.Context) (interface{}, error) {
+		log.Info().Msg("Get PON traffic with SNMP Get from Board ID: " + strconv.Itoa(boardID) + " PON ID: " + strconv.Itoa(ponID))
+
+		rack, shelf := u.getRackShelf(olt)
+		interfaces := []model.InterfaceTraffic{{
+			Name:    utils.GponOltName(shelf, boardID, ponID),
+			IfIndex: utils.GponIfIndex(rack, shelf, boardID, ponID),
+			Type:    model.InterfaceTypeGpon,
+			Board:   boardID,
+			PON:     ponID,
+		}}
+
+		if err := u.getInterfaceTraffic(ctx, olt, interfaces); err != nil {
+			return model.InterfaceTraffic{}, err
+		}
+		return interfaces[0], nil
+	})
+
+	if err != nil {
+		log.Error().Msg("Failed to get PON traffic: " + err.Error())
+		return model.InterfaceTraffic{}, err
+	}
+
+	return result.(model.InterfaceTraffic), nil
+}
+
+// getInterfaceTraffic is a function to get the counters of the interfaces in one batch and to calculate their rates
+// since the earlier samples kept in the cache
+func (u *onuUsecase) getInterfaceTraffic(ctx context.Context, olt *repository.OltConnection, interfaces []model.InterfaceTraffic) error {
+	oids := make([]string, 0, len(interfaces)*len(trafficFields))
+	for _, traffic := range interfaces {
+		for _, field := range trafficFields {
+			oids = append(oids, field.oid+"."+strconv.Itoa(traffic.IfIndex))
+		}
+	}
+
+	variables, err := olt.Snmp.GetBatch(ctx, oids)
+	if err != nil {
+		log.Error().Msg("Failed to perform SNMP Get for interface traffic: " + err.Error())
+		return fmt.Errorf("failed to perform SNMP Get: %w", err)
+	}
+	sampledAt := time.Now()
+
+	// Map each varbind back to its OID, gosnmp returns OIDs with a leading dot
+	snmpDataMap := make(map[string]gosnmp.SnmpPDU, len(variables))
+	for _, pdu := range variables {
+		snmpDataMap[strings.TrimPrefix(pdu.Name, ".")] = pdu
+	}
+
+	interval := u.getTrafficInterval()
+	for i := range interfaces {
+		traffic := &interfaces[i]
+		traffic.SampledAt = sampledAt
+
+		// Decode each counter, skipping counters the agent does not have
+		for _, field := range trafficFields {
+			pdu, ok := snmpDataMap[strings.TrimPrefix(field.oid, ".")+"."+strconv.Itoa(traffic.IfIndex)]
+			if !ok || !hasValue(pdu) {
+				continue
+			}
+			if value, ok := utils.ExtractCounter(pdu.Value); ok {
+				field.decode(value, traffic)
+			}
+		}
+
+		// Calculate the rates since the earlier samples
+		sample := toTrafficSample(*traffic)
+		sampleKey := fmt.Sprintf("traffic_sample_%s_%d", olt.Olt.ID, traffic.IfIndex)
+		if base, ok := u.sampleTraffic(ctx, sampleKey, sample, interval); ok {
+			setTrafficRates(traffic, base, sample)
+		}
+	}
+
+	return nil
+}
+
+// setONUTrafficRates sets the traffic rates of the ONU since the earlier sample of its counters, sampled at the given
+// time. Upstream is kept as the in and downstream as the out counters of the sample.
+func (u *onuUsecase) setONUTrafficRates(
+	ctx context.Context, olt *repository.OltConnection, info *model.ONUCustomerInfoV2, sampledAt time.Time,
+) {
+	traffic := &info.Traffic
+	if traffic.UpstreamBytes == nil && traffic.DownstreamBytes == nil {
+		return
+	}
+
+	sample := model.TrafficSample{Time: sampledAt}
+	for _, counter := range []struct {
+		value  *uint64
+		sample *uint64
+	}{
+		{traffic.UpstreamBytes, &sample.InOctets},
+		{traffic.DownstreamBytes, &sample.OutOctets},
+		{traffic.UpstreamPackets, &sample.InPackets},
+		{traffic.DownstreamPackets, &sample.OutPackets},
+	} {
+		if counter.value != nil {
+			*counter.sample = *counter.value
+		}
+	}
+
+	sampleKey := fmt.Sprintf("onu_traffic_sample_%s_%d_%d_%d", olt.Olt.ID, info.Board, info.PON, info.ID)
+	base, ok := u.sampleTraffic(ctx, sampleKey, sample, u.getOnuTrafficInterval())
+	if !ok {
+		return
+	}
+
+	seconds := sample.Time.Sub(base.Time).Seconds()
+	if seconds <= 0 {
+		return
+	}
+	traffic.IntervalSeconds = roundTo(seconds, 3)
+
+	// A Counter32 that decreased within its wrap time wrapped, 64-bit counters do not wrap
+	var octetWrapSeconds, packetWrapSeconds float64
+	if traffic.Counter32 {
+		octetWrapSeconds, packetWrapSeconds = onuOctetWrapSeconds, onuPacketWrapSeconds
+	}
+	if traffic.UpstreamBytes != nil {
+		traffic.UpstreamBps = counterRate(base.InOctets, sample.InOctets, 8, seconds, octetWrapSeconds)
+	}
+	if traffic.DownstreamBytes != nil {
+		traffic.DownstreamBps = counterRate(base.OutOctets, sample.OutOctets, 8, seconds, octetWrapSeconds)
+	}
+	if traffic.UpstreamPackets != nil {
+		traffic.UpstreamPps = counterRate(base.InPackets, sample.InPackets, 1, seconds, packetWrapSeconds)
+	}
+	if traffic.DownstreamPackets != nil {
+		traffic.DownstreamPps = counterRate(base.OutPackets, sample.OutPackets, 1, seconds, packetWrapSeconds)
+	}
+}
+
+// sampleTraffic returns the earlier sample the rates of the current sample are calculated over, then keeps the
+// current sample in the cache for the next request. ok is false without an earlier sample.
+func (u *onuUsecase) sampleTraffic(ctx context.Context, key string, sample model.TrafficSample, interval time.Duration) (
+	model.TrafficSample, bool,
+) {
+	samples, err := u.trafficCache.Get(ctx, key)
+	if err != nil {
+		samples = nil
+	}
+
+	base, ok := selectTrafficBase(samples, sample.Time, interval)
+
+	if samples, changed := appendTrafficSample(samples, sample, interval); changed {
+		ttl := interval * (trafficSampleCount + 1)
+		if err := u.trafficCache.Set(ctx, key, samples, ttl); err != nil {
+			log.Error().Msg("Failed to save traffic samples to cache: " + err.Error())
+		}
+	}
+
+	return base, ok
+}
+
+// toTrafficSample returns the counter sample of the interface traffic
+func toTrafficSample(traffic model.InterfaceTraffic) model.TrafficSample {
+	return model.TrafficSample{
+		Time:        traffic.SampledAt,
+		InOctets:    traffic.InOctets,
+		OutOctets:   traffic.OutOctets,
+		InErrors:    traffic.InErrors,
+		OutErrors:   traffic.OutErrors,
+		InDiscards:  traffic.InDiscards,
+		OutDiscards: traffic.OutDiscards,
+	}
+}
+
+// selectTrafficBase returns the latest sample at least the interval before now, or the oldest sample when all samples
+// are more recent. ok is false without an earlier sample.
+func selectTrafficBase(samples []model.TrafficSample, now time.Time, interval time.Duration) (model.TrafficSample, bool) {
+	for i := len(samples) - 1; i >= 0; i-- {
+		if now.Sub(samples[i].Time) >= interval {
+			return samples[i], true
+		}
+	}
+	if len(samples) > 0 && now.After(samples[0].Time) {
+		return samples[0], true
+	}
+	return model.TrafficSample{}, false
+}
+
+// appendTrafficSample keeps the sample when the latest sample is at least the interval old, dropping the oldest
+// samples. ok is false when the samples are unchanged.
+func appendTrafficSample(samples []model.TrafficSample, sample model.TrafficSample, interval time.Duration) (
+	[]model.TrafficSample, bool,
+) {
+	if len(samples) > 0 && sample.Time.Sub(samples[len(samples)-1].Time) < interval {
+		return samples, false
+	}
+
+	samples = append(samples, sample)
+	if len(samples) > trafficSampleCount {
+		samples = samples[len(samples)-trafficSampleCount:]
+	}
+	return samples, true
+}
+
+// setTrafficRates sets the bits per second and utilization rates of the traffic since the base sample, a counter that
+// decreased was reset and has no rate
+func setTrafficRates(traffic *model.InterfaceTraffic, base, sample model.TrafficSample) {
+	seconds := sample.Time.Sub(base.Time).Seconds()
+	if seconds <= 0 {
+		return
+	}
+	traffic.IntervalSeconds = roundTo(seconds, 3)
+
+	traffic.InBps = counterRate(base.InOctets, sample.InOctets, 8, seconds, 0)
+	if traffic.InBps != nil {
+		traffic.InUtilization = utilization(*traffic.InBps, traffic.SpeedMbps)
+	}
+	traffic.OutBps = counterRate(base.OutOctets, sample.OutOctets, 8, seconds, 0)
+	if traffic.OutBps != nil {
+		traffic.OutUtilization = utilization(*traffic.OutBps, traffic.SpeedMbps)
+	}
+}
+
+// counterRate returns the increase of the counter per second multiplied by the scale, e.g. 8 for bits of an octet
+// counter. A Counter32 that decreased in less than wrapSeconds wrapped once, 0 is a counter that does not wrap.
+// nil when the counter decreased otherwise, as it was reset.
+func counterRate(base, current uint64, scale, seconds, wrapSeconds float64) *float64 {
+	if current < base {
+		if seconds >= wrapSeconds || base >= counter32Range {
+			return nil
+		}
+		current += counter32Range
+	}
+	return roundTo(float64(current-base)*scale/seconds, 2)
+}
+
+// utilization returns the percentage of the interface speed used by the rate, nil when the speed is not known
+func utilization(bps float64, speedMbps *uint64) *float64 {
+	if speedMbps == nil || *speedMbps == 0 {
+		return nil
+	}
+	return roundTo(bps/(float64(*speedMbps)*1e6)*100, 2)
+}
+
+// roundTo rounds the value to the given decimals
+func roundTo(value float64, decimals int) *float64 {
+	scale := math.Pow(10, float64(decimals))
+	rounded := math.Round(value*scale) / scale
+	return &rounded
+}
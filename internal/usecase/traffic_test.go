@@ -0,0 +1,395 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/pkg/cache"
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectTrafficBase(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	samples := []model.TrafficSample{{Time: now.Add(-90 * time.Second)}, {Time: now.Add(-30 * time.Second)}}
+
+	// The latest sample at least the interval old
+	base, ok := selectTrafficBase(samples, now, time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(-90*time.Second), base.Time)
+
+	base, ok = selectTrafficBase(samples, now, 30*time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(-30*time.Second), base.Time)
+
+	// The oldest sample when all samples are more recent
+	base, ok = selectTrafficBase(samples, now, 5*time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(-90*time.Second), base.Time)
+
+	_, ok = selectTrafficBase(nil, now, time.Minute)
+	assert.False(t, ok)
+}
+
+func TestAppendTrafficSample(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	samples, ok := appendTrafficSample(nil, model.TrafficSample{Time: now}, time.Minute)
+	assert.True(t, ok)
+	assert.Len(t, samples, 1)
+
+	_, ok = appendTrafficSample(samples, model.TrafficSample{Time: now.Add(30 * time.Second)}, time.Minute)
+	assert.False(t, ok)
+
+	samples, ok = appendTrafficSample(samples, model.TrafficSample{Time: now.Add(time.Minute)}, time.Minute)
+	assert.True(t, ok)
+	samples, ok = appendTrafficSample(samples, model.TrafficSample{Time: now.Add(2 * time.Minute)}, time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, []model.TrafficSample{{Time: now.Add(time.Minute)}, {Time: now.Add(2 * time.Minute)}}, samples)
+}
+
+func TestSetTrafficRates(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	speed := uint64(2500)
+	traffic := model.InterfaceTraffic{SpeedMbps: &speed}
+
+	base := model.TrafficSample{Time: now, InOctets: 1000, OutOctets: 5000}
+	sample := model.TrafficSample{Time: now.Add(10 * time.Second), InOctets: 1000 + 31250000, OutOctets: 10}
+	setTrafficRates(&traffic, base, sample)
+
+	assert.Equal(t, 10.0, *traffic.IntervalSeconds)
+	assert.Equal(t, 25000000.0, *traffic.InBps)
+	assert.Equal(t, 1.0, *traffic.InUtilization)
+
+	// The out counter was reset
+	assert.Nil(t, traffic.OutBps)
+	assert.Nil(t, traffic.OutUtilization)
+}
+
+func TestGetTrafficWithReplay(t *testing.T) {
+	memory := cache.NewMemory(config.CacheConfig{})
+	uc := NewOnuUsecase(newReplayOltRepository(t, repository.OltConnection{}), memory, newTestConfig(true))
+
+	traffic, err := uc.GetTraffic(context.Background(), "")
+	assert.NoError(t, err)
+
+	// The 2 uplinks first, then the 32 GPON ports, without the management interface
+	assert.Len(t, traffic, 2+32)
+	assert.Equal(t, "xgei_1/3/1", traffic[0].Name)
+	assert.Equal(t, model.InterfaceTypeUplink, traffic[0].Type)
+	assert.Equal(t, uint64(10000), *traffic[0].SpeedMbps)
+	assert.Equal(t, "gpon-olt_1/1/1", traffic[2].Name)
+	assert.Equal(t, model.InterfaceTypeGpon, traffic[2].Type)
+	assert.Equal(t, 1, traffic[2].Board)
+	assert.Equal(t, 1, traffic[2].PON)
+
+	// Without an earlier sample there is no rate
+	assert.Nil(t, traffic[2].InBps)
+	assert.Equal(t, 34, memory.Len())
+}
+
+func TestGetPonTrafficWithReplay(t *testing.T) {
+	// The counters of board 1 PON 1 in the C320 walk fixture
+	const inOctets, outOctets = 101000000707, 404000000909
+
+	testCases := []struct {
+		name       string
+		boardID    int
+		base       *model.TrafficSample
+		wantIn     uint64
+		wantInBps  *float64
+		wantOutBps *float64
+	}{
+		// Without an earlier sample there is no rate
+		{name: "without earlier sample", boardID: 1, wantIn: inOctets},
+		{
+			name: "earlier sample", boardID: 1, wantIn: inOctets,
+			base:      &model.TrafficSample{InOctets: inOctets - 75000000, OutOctets: outOctets - 150000000},
+			wantInBps: ptr(10000000.0), wantOutBps: ptr(20000000.0),
+		},
+		// A counter that decreased was reset and has no rate
+		{
+			name: "counter reset", boardID: 1, wantIn: inOctets,
+			base:       &model.TrafficSample{InOctets: inOctets + 1000, OutOctets: outOctets - 150000000},
+			wantOutBps: ptr(20000000.0),
+		},
+		{name: "nonexistent board", boardID: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			memory := cache.NewMemory(config.CacheConfig{})
+			if tc.base != nil {
+				tc.base.Time = time.Now().Add(-time.Minute)
+				samples := cache.New[[]model.TrafficSample](memory)
+				err := samples.Set(context.Background(), "traffic_sample_default_285278465", []model.TrafficSample{*tc.base}, time.Hour)
+				assert.NoError(t, err)
+			}
+			uc := NewOnuUsecase(newReplayOltRepository(t, repository.OltConnection{}), memory, newTestConfig(true))
+
+			pon, err := uc.GetPonTraffic(context.Background(), "", tc.boardID, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantIn, pon.InOctets)
+			assertRate(t, tc.wantInBps, pon.InBps)
+			assertRate(t, tc.wantOutBps, pon.OutBps)
+		})
+	}
+}
+
+func TestGetPonTrafficCounters(t *testing.T) {
+	memory := cache.NewMemory(config.CacheConfig{})
+	uc := NewOnuUsecase(newReplayOltRepository(t, repository.OltConnection{}), memory, newTestConfig(true))
+
+	pon, err := uc.GetPonTraffic(context.Background(), "", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "gpon-olt_1/1/1", pon.Name)
+	assert.Equal(t, uint64(404000000909), pon.OutOctets)
+	assert.Equal(t, uint64(3), pon.InDiscards)
+	assert.Equal(t, uint64(1), pon.InErrors)
+	assert.Equal(t, uint64(2), pon.OutDiscards)
+	assert.Equal(t, uint64(1), pon.OutErrors)
+
+	// The counters did not change since the earlier sample
+	pon, err = uc.GetPonTraffic(context.Background(), "", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, *pon.InBps)
+	assert.Equal(t, 0.0, *pon.OutUtilization)
+	assert.NotNil(t, pon.IntervalSeconds)
+}
+
+func TestGetPonTrafficDecoding(t *testing.T) {
+	// The counters of board 1 PON 1 in the C320 walk fixture
+	const inErrorsOID, highSpeedOID = ".1.3.6.1.2.1.2.2.1.14.", ".1.3.6.1.2.1.31.1.1.1.15."
+
+	testCases := []struct {
+		name         string
+		snmp         repository.SnmpRepositoryInterface
+		wantInErrors uint64
+		wantSpeed    *uint64
+	}{
+		{name: "all columns", wantInErrors: 1, wantSpeed: ptr(uint64(2500))},
+		// Without the speed there is no utilization
+		{name: "missing speed column", snmp: newPatchedReplaySnmp(t, highSpeedOID+"285278465"), wantInErrors: 1},
+		{
+			name:      "error counter of the wrong type",
+			snmp:      newPatchedReplaySnmp(t, "", inErrorsOID+`285278465 = STRING: "1"`),
+			wantSpeed: ptr(uint64(2500)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			oltRepo := newReplayOltRepository(t, repository.OltConnection{Snmp: tc.snmp})
+			uc := NewOnuUsecase(oltRepo, cache.NewMemory(config.CacheConfig{}), newTestConfig(true))
+
+			// The second sample has the rates and utilization
+			_, err := uc.GetPonTraffic(context.Background(), "", 1, 1)
+			assert.NoError(t, err)
+			pon, err := uc.GetPonTraffic(context.Background(), "", 1, 1)
+			assert.NoError(t, err)
+
+			assert.Equal(t, uint64(101000000707), pon.InOctets)
+			assert.Equal(t, tc.wantInErrors, pon.InErrors)
+			assert.Equal(t, tc.wantSpeed, pon.SpeedMbps)
+			assert.Equal(t, tc.wantSpeed != nil, pon.InUtilization != nil)
+		})
+	}
+}
+
+func TestGetONUTrafficWithReplay(t *testing.T) {
+	// The counters of board 1 PON 1 ONU 1 in the C320 walk fixture
+	const upstreamBytes, upstreamPackets = 257001771003, 308658201
+
+	testCases := []struct {
+		name        string
+		base        *model.TrafficSample
+		wantUpBps   *float64
+		wantUpPps   *float64
+		wantSamples int
+	}{
+		// Without an earlier sample there is no rate
+		{name: "without earlier sample", wantSamples: 1},
+		{
+			name:      "earlier sample",
+			base:      &model.TrafficSample{InOctets: upstreamBytes - 75000000, InPackets: upstreamPackets - 6000},
+			wantUpBps: ptr(10000000.0), wantUpPps: ptr(100.0), wantSamples: 1,
+		},
+		// The 64-bit counters decreased, they were reset
+		{
+			name:      "counter reset",
+			base:      &model.TrafficSample{InOctets: upstreamBytes + 1000, InPackets: upstreamPackets - 6000},
+			wantUpPps: ptr(100.0), wantSamples: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			memory := cache.NewMemory(config.CacheConfig{})
+			if tc.base != nil {
+				tc.base.Time = time.Now().Add(-time.Minute)
+				samples := cache.New[[]model.TrafficSample](memory)
+				err := samples.Set(context.Background(), "onu_traffic_sample_default_1_1_1", []model.TrafficSample{*tc.base}, time.Hour)
+				assert.NoError(t, err)
+			}
+			uc := NewOnuUsecase(newReplayOltRepository(t, repository.OltConnection{}), memory, newTestConfig(true))
+
+			onu, err := uc.GetByBoardIDPonIDAndOnuIDV2(context.Background(), "", 1, 1, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(upstreamBytes), *onu.Traffic.UpstreamBytes)
+			assert.Equal(t, uint64(upstreamPackets), *onu.Traffic.UpstreamPackets)
+			assertRate(t, tc.wantUpBps, onu.Traffic.UpstreamBps)
+			assertRate(t, tc.wantUpPps, onu.Traffic.UpstreamPps)
+			assert.Equal(t, tc.wantSamples, memory.Len())
+		})
+	}
+}
+
+func TestGetONUTrafficCounters(t *testing.T) {
+	memory := cache.NewMemory(config.CacheConfig{})
+	uc := NewOnuUsecase(newReplayOltRepository(t, repository.OltConnection{}), memory, newTestConfig(true))
+
+	onu, err := uc.GetByBoardIDPonIDAndOnuIDV2(context.Background(), "", 1, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2056010313009), *onu.Traffic.DownstreamBytes)
+	assert.Equal(t, uint64(1543805007), *onu.Traffic.DownstreamPackets)
+	assert.Nil(t, onu.Traffic.IntervalSeconds)
+
+	// The counters did not change since the earlier sample
+	detail, err := uc.GetByBoardIDPonIDAndOnuID(context.Background(), "", 1, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "257001771003", detail.Traffic.UpstreamBytes)
+	assert.Equal(t, "0", detail.Traffic.UpstreamRate)
+	assert.Equal(t, "0", detail.Traffic.DownstreamRate)
+}
+
+func TestGetONUTrafficDecoding(t *testing.T) {
+	// The counter columns of the ONUs, the rows of board 1 PON 1 ONU 1 are indexed by 285278465.1
+	const counterOID = ".1.3.6.1.4.1.3902.1082.500.10.2.3.20.1."
+
+	testCases := []struct {
+		name          string
+		snmp          repository.SnmpRepositoryInterface
+		wantUpBytes   *uint64
+		wantUpPackets *uint64
+		wantCounter32 bool
+	}{
+		{name: "all columns", wantUpBytes: ptr(uint64(257001771003)), wantUpPackets: ptr(uint64(308658201))},
+		{
+			name:          "missing upstream bytes column",
+			snmp:          newPatchedReplaySnmp(t, counterOID+"2.285278465."),
+			wantUpPackets: ptr(uint64(308658201)),
+		},
+		{
+			name:        "upstream packets of the wrong type",
+			snmp:        newPatchedReplaySnmp(t, "", counterOID+`4.285278465.1 = STRING: "308658201"`),
+			wantUpBytes: ptr(uint64(257001771003)),
+		},
+		// Counter32 values wrap after 2^32-1, the rates account for it with the Counter32 flag
+		{
+			name:          "Counter32 counters",
+			snmp:          newPatchedReplaySnmp(t, "", counterOID+"4.285278465.1 = Counter32: 1000"),
+			wantUpBytes:   ptr(uint64(257001771003)),
+			wantUpPackets: ptr(uint64(1000)),
+			wantCounter32: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			oltRepo := newReplayOltRepository(t, repository.OltConnection{Snmp: tc.snmp})
+			uc := NewOnuUsecase(oltRepo, cache.NewMemory(config.CacheConfig{}), newTestConfig(true))
+
+			onu, err := uc.GetByBoardIDPonIDAndOnuIDV2(context.Background(), "", 1, 1, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantUpBytes, onu.Traffic.UpstreamBytes)
+			assert.Equal(t, tc.wantUpPackets, onu.Traffic.UpstreamPackets)
+			assert.Equal(t, tc.wantCounter32, onu.Traffic.Counter32)
+		})
+	}
+}
+
+// assertRate asserts that the rate is within 1% of the expected rate, or missing when no rate is expected
+func assertRate(t *testing.T, want, rate *float64) {
+	t.Helper()
+	if want == nil {
+		assert.Nil(t, rate)
+		return
+	}
+	if assert.NotNil(t, rate) {
+		assert.InEpsilon(t, *want, *rate, 0.01)
+	}
+}
+
+func TestCounterRate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		base        uint64
+		current     uint64
+		scale       float64
+		seconds     float64
+		wrapSeconds float64
+		want        *float64
+	}{
+		{"octets", 1000, 2000, 8, 10, 0, ptr(800.0)},
+		{"packets", 10, 15, 1, 10, 0, ptr(0.5)},
+		{"64-bit counter reset", 2000, 1000, 8, 10, 0, nil},
+		{"Counter32 wrap", counter32Range - 1000, 1000, 8, 10, onuOctetWrapSeconds, ptr(1600.0)},
+		{"Counter32 reset after the wrap time", counter32Range - 1000, 1000, 8, 20, onuOctetWrapSeconds, nil},
+		{"Counter32 packet wrap", counter32Range - 50, 50, 1, 100, onuPacketWrapSeconds, ptr(1.0)},
+		{"value above a Counter32", counter32Range + 1000, 1000, 8, 10, onuOctetWrapSeconds, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, counterRate(tc.base, tc.current, tc.scale, tc.seconds, tc.wrapSeconds))
+		})
+	}
+}
+
+// ptr returns a pointer to the value
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func TestSetONUTrafficRatesCounter32Wrap(t *testing.T) {
+	u := NewOnuUsecase(repository.NewOltRepository(), cache.NewMemory(config.CacheConfig{}), newTestConfig(true)).(*onuUsecase)
+	olt := &repository.OltConnection{Olt: model.Olt{ID: repository.DefaultOltID}}
+	sampledAt := time.Date(2024, 5, 14, 8, 0, 0, 0, time.UTC)
+
+	info := model.ONUCustomerInfoV2{Board: 1, PON: 1, ID: 1}
+	info.Traffic = model.ONUTrafficV2{
+		UpstreamBytes:   ptr(uint64(counter32Range - 1000)),
+		UpstreamPackets: ptr(uint64(counter32Range - 10)),
+		Counter32:       true,
+	}
+	u.setONUTrafficRates(context.Background(), olt, &info, sampledAt)
+	assert.Nil(t, info.Traffic.UpstreamBps)
+
+	// The Counter32 values wrapped since the earlier sample
+	info.Traffic = model.ONUTrafficV2{
+		UpstreamBytes:   ptr(uint64(1000)),
+		UpstreamPackets: ptr(uint64(10)),
+		Counter32:       true,
+	}
+	u.setONUTrafficRates(context.Background(), olt, &info, sampledAt.Add(10*time.Second))
+	assert.Equal(t, 1600.0, *info.Traffic.UpstreamBps)
+	assert.Equal(t, 2.0, *info.Traffic.UpstreamPps)
+}
+
+func TestDecodeCounter(t *testing.T) {
+	var traffic model.ONUTrafficV2
+
+	assert.NoError(t, decodeCounter(gosnmp.SnmpPDU{Type: gosnmp.Counter64, Value: uint64(1 << 40)}, &traffic.UpstreamBytes, &traffic))
+	assert.Equal(t, uint64(1<<40), *traffic.UpstreamBytes)
+	assert.False(t, traffic.Counter32)
+
+	assert.NoError(t, decodeCounter(gosnmp.SnmpPDU{Type: gosnmp.Counter32, Value: uint(1000)}, &traffic.DownstreamBytes, &traffic))
+	assert.Equal(t, uint64(1000), *traffic.DownstreamBytes)
+	assert.True(t, traffic.Counter32)
+
+	assert.Error(t, decodeCounter(gosnmp.SnmpPDU{Type: gosnmp.OctetString, Value: []byte("x")}, &traffic.UpstreamPackets, &traffic))
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/internal/utils"
+	"github.com/gosnmp/gosnmp"
+	"github.com/rs/zerolog/log"
+)
+
+// onuUniColumn is a per-ONU ETH UNI column indexed by port, with the setter of its ONUUniPort field
+type onuUniColumn struct {
+	oid string
+	set func(value interface{}, port *model.ONUUniPort)
+}
+
+// onuUniColumns returns the configured ETH UNI columns of an ONU, the port is appended per ETH UNI
+func (u *onuUsecase) onuUniColumns(olt *repository.OltConnection, oltConfig *model.OltConfig, onuID string) []onuUniColumn {
+	baseOID2 := getOltOID(u.cfg, olt).BaseOID2
+
+	// oid returns the OID of an ETH UNI column of the ONU, empty when the column is not configured
+	oid := func(attributeOID string) string {
+		if attributeOID == "" {
+			return ""
+		}
+		return baseOID2 + attributeOID + "." + onuID
+	}
+
+	columns := []onuUniColumn{
+		{oid(oltConfig.OnuEthAdminStateOID), func(value interface{}, port *model.ONUUniPort) {
+			port.AdminState = utils.ExtractUniAdminState(value)
+		}},
+		{oid(oltConfig.OnuEthLinkStatusOID), func(value interface{}, port *model.ONUUniPort) {
+			port.Link = utils.ExtractIfStatus(value)
+		}},
+		{oid(oltConfig.OnuEthSpeedDuplexOID), func(value interface{}, port *model.ONUUniPort) {
+			port.Speed, port.Duplex = utils.ExtractUniSpeedDuplex(value)
+		}},
+	}
+
+	// Skip the columns that are not configured, the ports are then read over telnet
+	configured := columns[:0]
+	for _, column := range columns {
+		if column.oid != "" {
+			configured = append(configured, column)
+		}
+	}
+	return configured
+}
+
+func (u *onuUsecase) GetONUUniPorts(ctx context.Context, oltID string, boardID, ponID, onuID int) (model.ONUUniPorts, error) {
+	// Get OLT connection
+	olt, err := u.getOlt(oltID)
+	if err != nil {
+		return model.ONUUniPorts{}, err
+	}
+
+	// Set key for simple flight
+	key := fmt.Sprintf("onu_uni:%s:%d:%d:%d", olt.Olt.ID, boardID, ponID, onuID)
+
+	// Using simple flight to prevent duplicate SNMP requests and telnet sessions
+	result, err := doShared(ctx, &u.sg, key, func(ctx context.Context) (interface{}, error) {
+		oltConfig, err := u.getOltConfig(olt, boardID, ponID) // Get OLT config based on Board ID and PON ID
+		if err != nil {
+			log.Error().Msg("Failed to get OLT Config: " + err.Error())
+			return model.ONUUniPorts{}, err
+		}
+
+		log.Info().Msg("Get ONU UNI ports with SNMP BulkWalk from Board ID: " + strconv.Itoa(boardID) +
+			" PON ID: " + strconv.Itoa(ponID) + " ONU ID: " + strconv.Itoa(onuID))
+
+		// The ONU does not exist when its name is not registered
+		if oltConfig.OnuIDNameOID == "" {
+			return model.ONUUniPorts{}, errors.New("ONU name OID is not configured")
+		}
+		nameOID := oltConfig.BaseOID + oltConfig.OnuIDNameOID + "." + strconv.Itoa(onuID)
+		variables, err := olt.Snmp.GetBatch(ctx, []string{nameOID})
+		if err != nil {
+			log.Error().Msg("Failed to perform SNMP Get for ONU name: " + err.Error())
+			return model.ONUUniPorts{}, fmt.Errorf("failed to perform SNMP Get: %w", err)
+		}
+		if len(variables) == 0 || !hasValue(variables[0]) {
+			return model.ONUUniPorts{}, nil
+		}
+
+		uni := model.ONUUniPorts{
+			Board: boardID,
+			PON:   ponID,
+			ID:    onuID,
+			Ports: make([]model.ONUUniPort, 0),
+		}
+
+		// ETH UNI columns joined by port
+		portMap := make(map[int]*model.ONUUniPort)
+		for _, column := range u.onuUniColumns(olt, oltConfig, strconv.Itoa(onuID)) {
+			err := olt.Snmp.BulkWalk(ctx, column.oid, func(pdu gosnmp.SnmpPDU) error {
+				portID, ok := utils.ExtractOnuIndex(pdu.Name, column.oid, "")
+				if !ok || !hasValue(pdu) {
+					return nil
+				}
+				port, ok := portMap[portID]
+				if !ok {
+					port = &model.ONUUniPort{
+						Port:       portID,
+						Name:       "eth_0/" + strconv.Itoa(portID),
+						Link:       "unknown",
+						AdminState: "unknown",
+					}
+					portMap[portID] = port
+				}
+				column.set(pdu.Value, port)
+				return nil
+			})
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return model.ONUUniPorts{}, ctxErr
+			}
+			if err != nil {
+				// Ports without a column are incomplete, like a PON listing without a column
+				log.Error().Msg("Failed to perform SNMP BulkWalk for OID " + column.oid + ": " + err.Error())
+				return model.ONUUniPorts{}, fmt.Errorf("failed to perform SNMP BulkWalk: %w", err)
+			}
+		}
+
+		if len(portMap) > 0 {
+			for _, port := range portMap {
+				uni.Ports = append(uni.Ports, *port)
+			}
+			sort.Slice(uni.Ports, func(i, j int) bool {
+				return uni.Ports[i].Port < uni.Ports[j].Port
+			})
+			uni.Source = model.SourceSnmp
+			return uni, nil
+		}
+
+		// Fall back to the CLI when the MIB does not have the ETH UNI ports
+		ports, err := u.getONUUniPortsWithTelnet(ctx, olt, boardID, ponID, onuID)
+		if err != nil {
+			log.Error().Msg("Failed to get ONU UNI ports with telnet: " + err.Error())
+			return model.ONUUniPorts{}, err
+		}
+		if len(ports) > 0 {
+			uni.Ports = ports
+			uni.Source = model.SourceTelnet
+		}
+
+		return uni, nil
+	})
+
+	if err != nil {
+		log.Error().Msg("Failed to get ONU UNI ports: " + err.Error())
+		return model.ONUUniPorts{}, err
+	}
+
+	return result.(model.ONUUniPorts), nil
+}
+
+// getONUUniPortsWithTelnet is a function to get the ETH UNI ports of an ONU with "show gpon remote-onu interface eth"
+func (u *onuUsecase) getONUUniPortsWithTelnet(ctx context.Context, olt *repository.OltConnection, boardID, ponID, onuID int) (
+	[]model.ONUUniPort, error,
+) {
+	if olt.Telnet == nil {
+		return nil, errors.New("telnet is not configured")
+	}
+
+	// Do not start a telnet session for a canceled request
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	_, shelf := u.getRackShelf(olt)
+	output, err := olt.Telnet.Run("show gpon remote-onu interface eth " + utils.GponOnuName(shelf, boardID, ponID, onuID))
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.ParseONUEthInterfaceOutput(output), nil
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/achyar10/snmp-olt-zte/internal/repository"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetONUUniPortsWithReplay(t *testing.T) {
+	telnetPorts := []model.ONUUniPort{
+		{Port: 1, Name: "eth_0/1", Link: "up", Speed: "100M", Duplex: "full", AdminState: "enabled"},
+	}
+
+	// The MIB has the ETH UNI ports of ONU 1 of board 1 PON 1, the ports of ONU 2 are read over telnet
+	testCases := []struct {
+		name         string
+		snmp         repository.SnmpRepositoryInterface
+		configure    func(cfg *config.Config)
+		onuID        int
+		wantSource   string
+		wantPorts    []model.ONUUniPort
+		wantCommands []string
+		wantErr      string
+	}{
+		{
+			name:       "MIB",
+			onuID:      1,
+			wantSource: model.SourceSnmp,
+			wantPorts: []model.ONUUniPort{
+				{Port: 1, Name: "eth_0/1", Link: "up", Speed: "1000M", Duplex: "full", AdminState: "enabled"},
+				{Port: 2, Name: "eth_0/2", Link: "down", AdminState: "enabled"},
+				{Port: 3, Name: "eth_0/3", Link: "down", AdminState: "disabled"},
+				{Port: 4, Name: "eth_0/4", Link: "up", Speed: "100M", Duplex: "half", AdminState: "enabled"},
+			},
+		},
+		{
+			// The ports keep the other columns, a link status that is not read is unknown
+			name:       "missing link status column",
+			snmp:       newPatchedReplaySnmp(t, ".1.3.6.1.4.1.3902.1012.3.50.14.1.1.5.268501248.1."),
+			onuID:      1,
+			wantSource: model.SourceSnmp,
+			wantPorts: []model.ONUUniPort{
+				{Port: 1, Name: "eth_0/1", Link: "unknown", Speed: "1000M", Duplex: "full", AdminState: "enabled"},
+				{Port: 2, Name: "eth_0/2", Link: "unknown", AdminState: "enabled"},
+				{Port: 3, Name: "eth_0/3", Link: "unknown", AdminState: "disabled"},
+				{Port: 4, Name: "eth_0/4", Link: "unknown", Speed: "100M", Duplex: "half", AdminState: "enabled"},
+			},
+		},
+		{
+			name:       "speed of the wrong type",
+			snmp:       newPatchedReplaySnmp(t, "", `.1.3.6.1.4.1.3902.1012.3.50.14.1.1.7.268501248.1.1 = STRING: "1000M"`),
+			onuID:      1,
+			wantSource: model.SourceSnmp,
+			wantPorts: []model.ONUUniPort{
+				{Port: 1, Name: "eth_0/1", Link: "up", AdminState: "enabled"},
+				{Port: 2, Name: "eth_0/2", Link: "down", AdminState: "enabled"},
+				{Port: 3, Name: "eth_0/3", Link: "down", AdminState: "disabled"},
+				{Port: 4, Name: "eth_0/4", Link: "up", Speed: "100M", Duplex: "half", AdminState: "enabled"},
+			},
+		},
+		{
+			name:         "telnet",
+			onuID:        2,
+			wantSource:   model.SourceTelnet,
+			wantPorts:    telnetPorts,
+			wantCommands: []string{"show gpon remote-onu interface eth gpon-onu_1/1/1:2"},
+		},
+		{
+			// Without ETH UNI columns the base OID is not walked, the ports are read over telnet
+			name: "without ETH UNI OIDs",
+			configure: func(cfg *config.Config) {
+				cfg.OltCfg.PonOID.OnuEthAdminStateOID = ""
+				cfg.OltCfg.PonOID.OnuEthLinkStatusOID = ""
+				cfg.OltCfg.PonOID.OnuEthSpeedDuplexOID = ""
+			},
+			onuID:        1,
+			wantSource:   model.SourceTelnet,
+			wantPorts:    telnetPorts,
+			wantCommands: []string{"show gpon remote-onu interface eth gpon-onu_1/1/1:1"},
+		},
+		// The ONU does not exist
+		{name: "nonexistent ONU", onuID: 100},
+		{
+			name: "without name OID",
+			configure: func(cfg *config.Config) {
+				cfg.OltCfg.PonOID.OnuIDNameOID = ""
+			},
+			onuID: 1, wantErr: "ONU name OID is not configured",
+		},
+		{
+			// Ports without their link status are not returned as complete
+			name: "failed column",
+			snmp: columnErrorSnmp{
+				SnmpRepositoryInterface: newReplaySnmp(t),
+				prefix:                  ".1.3.6.1.4.1.3902.1012.3.50.14.1.1.5.",
+				err:                     snmp.ErrCircuitOpen,
+			},
+			onuID: 1, wantErr: snmp.ErrCircuitOpen.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig(true)
+			if tc.configure != nil {
+				tc.configure(cfg)
+			}
+			var commands []string
+			oltRepo := newReplayOltRepository(t, repository.OltConnection{
+				Snmp: tc.snmp,
+				Telnet: telnetStub{
+					output:   "Interface: eth_0/1\nSpeed status: full-100\nOperate status: enable\nAdmin status: enable\n",
+					commands: &commands,
+				},
+			})
+			uc := NewOnuUsecase(oltRepo, cacheStub{}, cfg)
+
+			uni, err := uc.GetONUUniPorts(context.Background(), "", 1, 1, tc.onuID)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantSource, uni.Source)
+			assert.Equal(t, tc.wantPorts, uni.Ports)
+			assert.Equal(t, tc.wantCommands, commands)
+		})
+	}
+}
+
+func TestOnuUniColumnsSkipsUnconfiguredOIDs(t *testing.T) {
+	cfg := newTestConfig(true)
+	cfg.OltCfg.PonOID.OnuEthAdminStateOID = ""
+	cfg.OltCfg.PonOID.OnuEthLinkStatusOID = ""
+	cfg.OltCfg.PonOID.OnuEthSpeedDuplexOID = ""
+	u := &onuUsecase{cfg: cfg}
+
+	olt := &repository.OltConnection{}
+	oltConfig, err := u.getOltConfig(olt, 1, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, u.onuUniColumns(olt, oltConfig, "1"))
+}
@@ -43,12 +43,23 @@ func ConvertDurationToString(duration time.Duration) string {
 	return strconv.Itoa(days) + " days " + strconv.Itoa(hours) + " hours " + strconv.Itoa(minutes) + " minutes " + strconv.Itoa(seconds) + " seconds"
 }
 
-// ConvertByteArrayToDateTime Convert byte array to human-readable date time
+// ConvertByteArrayToDateTime Convert byte array to human-readable date time, in the time zone of the value if it has one
 func ConvertByteArrayToDateTime(byteArray []byte) (string, error) {
+	datetime, err := ConvertByteArrayToTime(byteArray, time.UTC)
+	if err != nil {
+		return "", err
+	}
+
+	return datetime.Format("2006-01-02 15:04:05"), nil
+}
+
+// ConvertByteArrayToTime Convert an RFC 2579 DateAndTime byte array to time.
+// The 8 byte form has no UTC offset and is read in loc, the 11 byte form carries its own UTC offset.
+func ConvertByteArrayToTime(byteArray []byte, loc *time.Location) (time.Time, error) {
 
-	// Check if byteArray length is exactly 8
-	if len(byteArray) != 8 {
-		return "", errors.New("invalid byte array length: expected 8 bytes")
+	// Check if byteArray length is 8 or 11
+	if len(byteArray) != 8 && len(byteArray) != 11 {
+		return time.Time{}, errors.New("invalid byte array length: expected 8 or 11 bytes")
 	}
 
 	// Extract the year from the first two bytes
@@ -60,27 +71,47 @@ func ConvertByteArrayToDateTime(byteArray []byte) (string, error) {
 	hour := int(byteArray[4])         // Hour
 	minute := int(byteArray[5])       // Minute
 	second := int(byteArray[6])       // Second
+	deciSecond := int(byteArray[7])   // Tenths of a second
 
 	// Validate extracted values
 	if month < 1 || month > 12 {
-		return "", fmt.Errorf("invalid month: %d", month)
+		return time.Time{}, fmt.Errorf("invalid month: %d", month)
 	}
 	if day < 1 || day > 31 {
-		return "", fmt.Errorf("invalid day: %d", day)
+		return time.Time{}, fmt.Errorf("invalid day: %d", day)
 	}
 	if hour < 0 || hour > 23 {
-		return "", fmt.Errorf("invalid hour: %d", hour)
+		return time.Time{}, fmt.Errorf("invalid hour: %d", hour)
 	}
 	if minute < 0 || minute > 59 {
-		return "", fmt.Errorf("invalid minute: %d", minute)
+		return time.Time{}, fmt.Errorf("invalid minute: %d", minute)
 	}
 	if second < 0 || second > 59 {
-		return "", fmt.Errorf("invalid second: %d", second)
+		return time.Time{}, fmt.Errorf("invalid second: %d", second)
+	}
+	if deciSecond > 9 {
+		return time.Time{}, fmt.Errorf("invalid deci-second: %d", deciSecond)
 	}
 
-	// Create a time.Time object UTC
-	datetime := time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+	if loc == nil {
+		loc = time.UTC
+	}
 
-	// Convert to Unix epoch time (seconds since Jan 1, 1970)
-	return datetime.Format("2006-01-02 15:04:05"), nil
+	// Extract the UTC offset: direction ('+' or '-'), hours and minutes
+	if len(byteArray) == 11 {
+		direction := byteArray[8]
+		offsetHours := int(byteArray[9])
+		offsetMinutes := int(byteArray[10])
+		if (direction != '+' && direction != '-') || offsetHours > 14 || offsetMinutes > 59 {
+			return time.Time{}, fmt.Errorf("invalid UTC offset: %c%d:%d", direction, offsetHours, offsetMinutes)
+		}
+
+		offset := offsetHours*3600 + offsetMinutes*60
+		if direction == '-' {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+
+	return time.Date(year, month, day, hour, minute, second, deciSecond*int(100*time.Millisecond), loc), nil
 }
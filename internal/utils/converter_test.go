@@ -165,3 +165,61 @@ func TestConvertByteArrayToDateTime(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertByteArrayToTime(t *testing.T) {
+	wib := time.FixedZone("WIB", 7*3600)
+
+	tests := []struct {
+		name          string
+		byteArray     []byte
+		loc           *time.Location
+		expected      time.Time
+		expectedError bool
+	}{
+		{
+			name:      "Without UTC offset in the given location",
+			byteArray: []byte{0x07, 0xe8, 0x05, 0x0e, 0x08, 0x01, 0x07, 0x05},
+			loc:       wib,
+			expected:  time.Date(2024, 5, 14, 1, 1, 7, 500*int(time.Millisecond), time.UTC),
+		},
+		{
+			name:      "Without UTC offset and location",
+			byteArray: []byte{0x07, 0xe8, 0x05, 0x0e, 0x08, 0x01, 0x07, 0x00},
+			expected:  time.Date(2024, 5, 14, 8, 1, 7, 0, time.UTC),
+		},
+		{
+			name:      "With UTC offset east of UTC",
+			byteArray: []byte{0x07, 0xe8, 0x05, 0x0e, 0x08, 0x01, 0x07, 0x00, '+', 0x07, 0x00},
+			loc:       time.UTC,
+			expected:  time.Date(2024, 5, 14, 1, 1, 7, 0, time.UTC),
+		},
+		{
+			name:      "With UTC offset west of UTC",
+			byteArray: []byte{0x07, 0xe8, 0x05, 0x0e, 0x08, 0x01, 0x07, 0x00, '-', 0x03, 0x1e},
+			loc:       wib,
+			expected:  time.Date(2024, 5, 14, 11, 31, 7, 0, time.UTC),
+		},
+		{
+			name:          "Invalid UTC offset direction",
+			byteArray:     []byte{0x07, 0xe8, 0x05, 0x0e, 0x08, 0x01, 0x07, 0x00, 'x', 0x07, 0x00},
+			expectedError: true,
+		},
+		{
+			name:          "Invalid deci-second",
+			byteArray:     []byte{0x07, 0xe8, 0x05, 0x0e, 0x08, 0x01, 0x07, 0x0a},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ConvertByteArrayToTime(tt.byteArray, tt.loc)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(result), "expected %v, got %v", tt.expected, result)
+		})
+	}
+}
@@ -40,3 +40,12 @@ func ErrorNotFound(w http.ResponseWriter, err error) {
 	}
 	SendJSONResponse(w, http.StatusNotFound, webResponse)
 }
+
+func ErrorServiceUnavailable(w http.ResponseWriter, err error) {
+	webResponse := ErrorResponse{
+		Code:    http.StatusServiceUnavailable,
+		Status:  "Service Unavailable",
+		Message: err.Error(),
+	}
+	SendJSONResponse(w, http.StatusServiceUnavailable, webResponse)
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"fmt"
+	"math"
+	"net"
 	"strconv"
 	"strings"
 )
@@ -40,6 +42,46 @@ func ExtractIDOnuID(oid interface{}) int {
 	}
 }
 
+// ExtractOnuIndex extracts the ONU ID from an OID of a per-PON column, e.g. <column>.<onu_id><suffix>
+func ExtractOnuIndex(oid, columnOID, suffix string) (int, bool) {
+	// Remove the leading dot, since gosnmp returns OIDs with a leading dot
+	oid = strings.TrimPrefix(oid, ".")
+	columnOID = strings.TrimPrefix(columnOID, ".")
+
+	if !strings.HasPrefix(oid, columnOID+".") || !strings.HasSuffix(oid, suffix) {
+		return 0, false
+	}
+
+	index := strings.TrimSuffix(strings.TrimPrefix(oid, columnOID+"."), suffix)
+	onuID, err := strconv.Atoi(index)
+	if err != nil {
+		return 0, false
+	}
+	return onuID, true
+}
+
+// ExtractTableIndex extracts the index components from an OID of a table column, e.g. <column>.<rack>.<shelf>.<slot>
+func ExtractTableIndex(oid, columnOID string) ([]int, bool) {
+	// Remove the leading dot, since gosnmp returns OIDs with a leading dot
+	oid = strings.TrimPrefix(oid, ".")
+	columnOID = strings.TrimPrefix(columnOID, ".")
+
+	if !strings.HasPrefix(oid, columnOID+".") {
+		return nil, false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(oid, columnOID+"."), ".")
+	index := make([]int, len(parts))
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		index[i] = value
+	}
+	return index, true
+}
+
 func ExtractName(oidValue interface{}) string {
 	switch v := oidValue.(type) {
 	case string:
@@ -54,6 +96,21 @@ func ExtractName(oidValue interface{}) string {
 	}
 }
 
+// ExtractMacAddress returns a MAC address as aa:bb:cc:dd:ee:ff, a value that is not 6 bytes is returned as string
+func ExtractMacAddress(oidValue interface{}) string {
+	switch v := oidValue.(type) {
+	case string:
+		return v
+	case []byte:
+		if len(v) != 6 {
+			return string(v)
+		}
+		return net.HardwareAddr(v).String()
+	default:
+		return ""
+	}
+}
+
 // ExtractSerialNumber function is used to extract serial number from OID value
 func ExtractSerialNumber(oidValue interface{}) string {
 	switch v := oidValue.(type) {
@@ -77,22 +134,209 @@ func ExtractSerialNumber(oidValue interface{}) string {
 }
 
 func ConvertAndMultiply(pduValue interface{}) (string, error) {
+	result, err := ExtractOpticalPower(pduValue)
+	if err != nil {
+		return "", err
+	}
+
+	// Convert the result to a string with two decimal places
+	resultStr := strconv.FormatFloat(result, 'f', 2, 64)
+
+	return resultStr, nil
+}
+
+// ExtractOpticalPower converts a ZTE optical power value in 0.002 dBm steps offset by 30 dBm to dBm
+func ExtractOpticalPower(pduValue interface{}) (float64, error) {
 	// Type assert pduValue to an integer type
 	intValue, ok := pduValue.(int)
 	if !ok {
-		return "", fmt.Errorf("value is not an integer")
+		return 0, fmt.Errorf("value is not an integer")
 	}
 
-	// Multiply the integer by 0.002
-	result := float64(intValue) * 0.002
+	// Multiply the integer by 0.002 and subtract 30, rounded to the 0.001 dBm resolution of the value
+	return math.Round((float64(intValue)*0.002-30.0)*1000) / 1000, nil
+}
 
-	// Subtract 30
-	result -= 30.0
+// transceiverNoReading is the raw DDM value of an ONU without a reading, e.g. an offline ONU
+const transceiverNoReading = 65535
 
-	// Convert the result to a string with two decimal places
-	resultStr := strconv.FormatFloat(result, 'f', 2, 64)
+// ExtractTransceiverTemperature converts an ONU optical module temperature in 1/256 °C steps (two's complement, as the
+// ITU-T G.988 ANI-G test result) to °C
+func ExtractTransceiverTemperature(pduValue interface{}) (float64, error) {
+	raw, err := extractTransceiverValue(pduValue)
+	if err != nil {
+		return 0, err
+	}
 
-	return resultStr, nil
+	// Rounded to 0.01 °C
+	return math.Round(float64(int16(raw))/256*100) / 100, nil
+}
+
+// ExtractTransceiverVoltage converts an ONU optical module supply voltage in 20 mV steps to V
+func ExtractTransceiverVoltage(pduValue interface{}) (float64, error) {
+	raw, err := extractTransceiverValue(pduValue)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Round(float64(raw)*0.02*100) / 100, nil
+}
+
+// ExtractTransceiverBiasCurrent converts an ONU laser bias current in 2 µA steps to mA
+func ExtractTransceiverBiasCurrent(pduValue interface{}) (float64, error) {
+	raw, err := extractTransceiverValue(pduValue)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Round(float64(raw)*0.002*1000) / 1000, nil
+}
+
+// extractTransceiverValue returns the 16 bit raw value of an ONU DDM varbind
+func extractTransceiverValue(pduValue interface{}) (uint16, error) {
+	intValue, ok := pduValue.(int)
+	if !ok {
+		return 0, fmt.Errorf("value is not an integer")
+	}
+	if intValue == transceiverNoReading {
+		return 0, fmt.Errorf("no reading")
+	}
+	if intValue < math.MinInt16 || intValue > math.MaxUint16 {
+		return 0, fmt.Errorf("value out of range: %d", intValue)
+	}
+
+	return uint16(intValue), nil
+}
+
+// ExtractIfStatus returns the name of an IF-MIB ifAdminStatus or ifOperStatus value
+func ExtractIfStatus(oidValue interface{}) string {
+	intValue, ok := oidValue.(int)
+	if !ok {
+		return "unknown"
+	}
+
+	switch intValue {
+	case 1:
+		return "up"
+	case 2:
+		return "down"
+	case 3:
+		return "testing"
+	case 5:
+		return "dormant"
+	case 6:
+		return "notPresent"
+	case 7:
+		return "lowerLayerDown"
+	default:
+		return "unknown"
+	}
+}
+
+// ExtractInteger returns the value of an INTEGER varbind, ok is false for other types
+func ExtractInteger(oidValue interface{}) (int, bool) {
+	intValue, ok := oidValue.(int)
+	return intValue, ok
+}
+
+// ExtractCounter returns the value of a Counter32, Counter64 or Gauge32 varbind, ok is false for other types
+func ExtractCounter(oidValue interface{}) (uint64, bool) {
+	switch value := oidValue.(type) {
+	case uint:
+		return uint64(value), true
+	case uint32:
+		return uint64(value), true
+	case uint64:
+		return value, true
+	}
+	return 0, false
+}
+
+// ExtractUniAdminState returns the name of an ITU-T G.988 administrative state of an ONU UNI: unlocked(0) is enabled,
+// locked(1) is disabled
+func ExtractUniAdminState(oidValue interface{}) string {
+	intValue, ok := oidValue.(int)
+	if !ok {
+		return "unknown"
+	}
+
+	switch intValue {
+	case 0:
+		return "enabled"
+	case 1:
+		return "disabled"
+	default:
+		return "unknown"
+	}
+}
+
+// ExtractUniSpeedDuplex returns the speed and duplex of an ITU-T G.988 configuration indication of an ETH UNI, empty
+// without link
+func ExtractUniSpeedDuplex(oidValue interface{}) (speed, duplex string) {
+	intValue, ok := oidValue.(int)
+	if !ok {
+		return "", ""
+	}
+
+	speeds := map[int]string{0x1: "10M", 0x2: "100M", 0x3: "1000M", 0x4: "10G", 0x5: "2.5G", 0x6: "5G", 0x7: "25G", 0x8: "40G"}
+	switch {
+	case speeds[intValue] != "":
+		return speeds[intValue], "full"
+	case intValue > 0x10 && speeds[intValue-0x10] != "":
+		return speeds[intValue-0x10], "half"
+	default:
+		return "", ""
+	}
+}
+
+// ExtractCardStatus returns the name of the operational status of a card of the OLT chassis
+func ExtractCardStatus(oidValue interface{}) string {
+	intValue, ok := oidValue.(int)
+	if !ok {
+		return "unknown"
+	}
+
+	switch intValue {
+	case 1:
+		return "inService"
+	case 2:
+		return "notInService"
+	case 3:
+		return "hwOnline"
+	case 4:
+		return "hwOffline"
+	case 5:
+		return "configuring"
+	case 6:
+		return "configFailed"
+	case 7:
+		return "typeMismatch"
+	case 8:
+		return "deactivated"
+	case 9:
+		return "faulty"
+	default:
+		return "unknown"
+	}
+}
+
+// ExtractDeviceStatus returns the name of the status of a fan or power supply of the OLT chassis
+func ExtractDeviceStatus(oidValue interface{}) string {
+	intValue, ok := oidValue.(int)
+	if !ok {
+		return "unknown"
+	}
+
+	switch intValue {
+	case 1:
+		return "normal"
+	case 2:
+		return "fault"
+	case 3:
+		return "absent"
+	default:
+		return "unknown"
+	}
 }
 
 func ExtractAndGetStatus(oidValue interface{}) string {
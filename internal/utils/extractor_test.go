@@ -115,6 +115,132 @@ func TestConvertAndMultiply(t *testing.T) {
 	}
 }
 
+func TestExtractOpticalPower(t *testing.T) {
+	testCases := []struct {
+		pduValue interface{}
+		expected float64
+		err      bool
+	}{
+		{5375, -19.25, false},
+		{5372, -19.256, false},
+		{15000, 0, false},
+		{"string", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("PDUValue: %v", tc.pduValue), func(t *testing.T) {
+			result, err := ExtractOpticalPower(tc.pduValue)
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestExtractTransceiverValues(t *testing.T) {
+	testCases := []struct {
+		extract  func(interface{}) (float64, error)
+		pduValue interface{}
+		expected float64
+		err      bool
+	}{
+		{ExtractTransceiverTemperature, 11648, 45.5, false},
+		{ExtractTransceiverTemperature, 65280, -1, false}, // Two's complement
+		{ExtractTransceiverTemperature, -256, -1, false},
+		{ExtractTransceiverVoltage, 165, 3.3, false},
+		{ExtractTransceiverBiasCurrent, 6250, 12.5, false},
+		{ExtractTransceiverBiasCurrent, 65535, 0, true}, // No reading
+		{ExtractTransceiverVoltage, 70000, 0, true},
+		{ExtractTransceiverVoltage, "string", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("PDUValue: %v", tc.pduValue), func(t *testing.T) {
+			result, err := tc.extract(tc.pduValue)
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestExtractIfStatus(t *testing.T) {
+	assert.Equal(t, "up", ExtractIfStatus(1))
+	assert.Equal(t, "down", ExtractIfStatus(2))
+	assert.Equal(t, "lowerLayerDown", ExtractIfStatus(7))
+	assert.Equal(t, "unknown", ExtractIfStatus(4))
+	assert.Equal(t, "unknown", ExtractIfStatus("up"))
+}
+
+func TestExtractMacAddress(t *testing.T) {
+	assert.Equal(t, "00:1a:2b:3c:4d:5e", ExtractMacAddress([]byte{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}))
+	assert.Equal(t, "001a.2b3c.4d5e", ExtractMacAddress("001a.2b3c.4d5e"))
+	assert.Equal(t, "", ExtractMacAddress(42))
+}
+
+func TestExtractUniAdminState(t *testing.T) {
+	assert.Equal(t, "enabled", ExtractUniAdminState(0))
+	assert.Equal(t, "disabled", ExtractUniAdminState(1))
+	assert.Equal(t, "unknown", ExtractUniAdminState(2))
+	assert.Equal(t, "unknown", ExtractUniAdminState("0"))
+}
+
+func TestExtractCardStatus(t *testing.T) {
+	assert.Equal(t, "inService", ExtractCardStatus(1))
+	assert.Equal(t, "hwOffline", ExtractCardStatus(4))
+	assert.Equal(t, "faulty", ExtractCardStatus(9))
+	assert.Equal(t, "unknown", ExtractCardStatus(0))
+	assert.Equal(t, "unknown", ExtractCardStatus("1"))
+}
+
+func TestExtractDeviceStatus(t *testing.T) {
+	assert.Equal(t, "normal", ExtractDeviceStatus(1))
+	assert.Equal(t, "fault", ExtractDeviceStatus(2))
+	assert.Equal(t, "absent", ExtractDeviceStatus(3))
+	assert.Equal(t, "unknown", ExtractDeviceStatus(4))
+	assert.Equal(t, "unknown", ExtractDeviceStatus(nil))
+}
+
+func TestExtractUniSpeedDuplex(t *testing.T) {
+	testCases := []struct {
+		oidValue interface{}
+		speed    string
+		duplex   string
+	}{
+		{0x03, "1000M", "full"},
+		{0x02, "100M", "full"},
+		{0x12, "100M", "half"},
+		{0x00, "", ""}, // No link
+		{0x09, "", ""},
+		{"1000M", "", ""},
+	}
+
+	for _, tc := range testCases {
+		speed, duplex := ExtractUniSpeedDuplex(tc.oidValue)
+		assert.Equal(t, tc.speed, speed, "%v", tc.oidValue)
+		assert.Equal(t, tc.duplex, duplex, "%v", tc.oidValue)
+	}
+}
+
+func TestExtractCounter(t *testing.T) {
+	counter, ok := ExtractCounter(uint64(12345678901))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(12345678901), counter)
+
+	counter, ok = ExtractCounter(uint(42))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(42), counter)
+
+	_, ok = ExtractCounter(42)
+	assert.False(t, ok)
+}
+
 func TestExtractAndGetStatus(t *testing.T) {
 	testCases := []struct {
 		oidValue interface{}
@@ -284,3 +410,43 @@ func TestExtractGponOpticalDistance(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractOnuIndex(t *testing.T) {
+	testCases := []struct {
+		oid       string
+		columnOID string
+		suffix    string
+		expected  int
+		ok        bool
+	}{
+		{".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.2.285278465.12", ".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.2.285278465", "", 12, true},
+		{"1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.2.285278465.12", ".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.2.285278465", "", 12, true},
+		{".1.3.6.1.4.1.3902.1082.500.20.2.2.2.1.10.285278465.7.1", ".1.3.6.1.4.1.3902.1082.500.20.2.2.2.1.10.285278465", ".1", 7, true},
+		{".1.3.6.1.4.1.3902.1082.500.20.2.2.2.1.10.285278465.7.2", ".1.3.6.1.4.1.3902.1082.500.20.2.2.2.1.10.285278465", ".1", 0, false},
+		{".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.2.2852784651.1", ".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.2.285278465", "", 0, false},
+		{".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.2.285278466.1", ".1.3.6.1.4.1.3902.1082.500.10.2.3.3.1.2.285278465", "", 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.oid, func(t *testing.T) {
+			onuID, ok := ExtractOnuIndex(tc.oid, tc.columnOID, tc.suffix)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, onuID)
+		})
+	}
+}
+
+func TestExtractTableIndex(t *testing.T) {
+	index, ok := ExtractTableIndex(".1.3.6.1.4.1.3902.1012.3.3.1.1.4.1.1.2", ".1.3.6.1.4.1.3902.1012.3.3.1.1.4")
+	assert.True(t, ok)
+	assert.Equal(t, []int{1, 1, 2}, index)
+
+	index, ok = ExtractTableIndex("1.3.6.1.4.1.3902.1012.3.6.1.1.3.2", ".1.3.6.1.4.1.3902.1012.3.6.1.1.3")
+	assert.True(t, ok)
+	assert.Equal(t, []int{2}, index)
+
+	_, ok = ExtractTableIndex(".1.3.6.1.4.1.3902.1012.3.3.1.1.5.1.1.2", ".1.3.6.1.4.1.3902.1012.3.3.1.1.4")
+	assert.False(t, ok)
+	_, ok = ExtractTableIndex(".1.3.6.1.4.1.3902.1012.3.3.1.1.4", ".1.3.6.1.4.1.3902.1012.3.3.1.1.4")
+	assert.False(t, ok)
+}
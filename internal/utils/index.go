@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	gponIfIndexType  = 1 // Interface type of gpon-olt ports in the ZTE ifIndex encoding
+	ponIfIndexFormat = "{if_index}"
+	ponIndexFormat   = "{pon_index}"
+)
+
+// GponIfIndex calculates the ZTE ifIndex of gpon-olt_rack/shelf/slot/port
+// e.g. gpon-olt_1/1/1 = 0x11010101 = 285278465
+func GponIfIndex(rack, shelf, slot, port int) int {
+	return gponIfIndexType<<28 | rack<<24 | shelf<<16 | slot<<8 | port
+}
+
+// GponPonIndex calculates the ZTE PON index used by the ONU tables under base OID 2
+// e.g. gpon-olt_1/1/1 = 0x10010100 = 268501248
+func GponPonIndex(shelf, slot, port int) int {
+	return gponIfIndexType<<28 | (shelf-1)<<24 | slot<<16 | port<<8
+}
+
+// ParseGponIfIndex decodes a ZTE gpon-olt ifIndex into its rack, shelf, slot and port
+func ParseGponIfIndex(ifIndex int) (rack, shelf, slot, port int, err error) {
+	if ifIndex>>28 != gponIfIndexType {
+		return 0, 0, 0, 0, fmt.Errorf("invalid gpon-olt ifIndex: %d", ifIndex)
+	}
+
+	rack = ifIndex >> 24 & 0x0f
+	shelf = ifIndex >> 16 & 0xff
+	slot = ifIndex >> 8 & 0xff
+	port = ifIndex & 0xff
+
+	return rack, shelf, slot, port, nil
+}
+
+// GponOltName returns the CLI name of a gpon-olt port, e.g. gpon-olt_1/1/1
+func GponOltName(shelf, slot, port int) string {
+	return fmt.Sprintf("gpon-olt_%d/%d/%d", shelf, slot, port)
+}
+
+// GponOnuName returns the CLI name of a gpon-onu interface, e.g. gpon-onu_1/1/1:1
+func GponOnuName(shelf, slot, port, onuID int) string {
+	return fmt.Sprintf("gpon-onu_%d/%d/%d:%d", shelf, slot, port, onuID)
+}
+
+// BuildPonOID replaces the {if_index} and {pon_index} placeholders of a per-PON OID template
+func BuildPonOID(template string, ifIndex, ponIndex int) string {
+	oid := strings.ReplaceAll(template, ponIfIndexFormat, strconv.Itoa(ifIndex))
+	return strings.ReplaceAll(oid, ponIndexFormat, strconv.Itoa(ponIndex))
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGponIfIndex(t *testing.T) {
+	testCases := []struct {
+		slot     int
+		port     int
+		expected int
+	}{
+		{1, 1, 285278465},
+		{1, 2, 285278466},
+		{1, 16, 285278480},
+		{2, 1, 285278721},
+		{2, 16, 285278736},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("gpon-olt_1/%d/%d", tc.slot, tc.port), func(t *testing.T) {
+			assert.Equal(t, tc.expected, GponIfIndex(1, 1, tc.slot, tc.port))
+		})
+	}
+}
+
+func TestGponPonIndex(t *testing.T) {
+	testCases := []struct {
+		slot     int
+		port     int
+		expected int
+	}{
+		{1, 1, 268501248},
+		{1, 2, 268501504},
+		{1, 16, 268505088},
+		{2, 1, 268566784},
+		{2, 16, 268570624},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("gpon-olt_1/%d/%d", tc.slot, tc.port), func(t *testing.T) {
+			assert.Equal(t, tc.expected, GponPonIndex(1, tc.slot, tc.port))
+		})
+	}
+}
+
+func TestParseGponIfIndex(t *testing.T) {
+	rack, shelf, slot, port, err := ParseGponIfIndex(285278736)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 1, 2, 16}, []int{rack, shelf, slot, port})
+
+	_, _, _, _, err = ParseGponIfIndex(12345)
+	assert.Error(t, err)
+}
+
+func TestGponOltName(t *testing.T) {
+	assert.Equal(t, "gpon-olt_1/2/16", GponOltName(1, 2, 16))
+}
+
+func TestGponOnuName(t *testing.T) {
+	assert.Equal(t, "gpon-onu_1/2/16:128", GponOnuName(1, 2, 16, 128))
+}
+
+func TestBuildPonOID(t *testing.T) {
+	assert.Equal(t, ".500.10.2.3.3.1.2.285278465",
+		BuildPonOID(".500.10.2.3.3.1.2.{if_index}", 285278465, 268501248))
+	assert.Equal(t, ".3.50.11.2.1.17.268501248",
+		BuildPonOID(".3.50.11.2.1.17.{pon_index}", 285278465, 268501248))
+	assert.Equal(t, ".1.2.3", BuildPonOID(".1.2.3", 285278465, 268501248))
+}
@@ -9,7 +9,8 @@ import (
 	"github.com/achyar10/snmp-olt-zte/internal/model"
 )
 
-func GetAvailableONUOnly(oltIndex string, max int) ([]model.ONUStatus, error) {
+// GetAvailableONUOnly returns the unused ONU IDs of a PON port using the given telnet command runner
+func GetAvailableONUOnly(run func(command string) (string, error), oltIndex string, max int) ([]model.ONUStatus, error) {
 
 	slot, port, err := ParseOltIndex(oltIndex)
 	if err != nil {
@@ -17,7 +18,7 @@ func GetAvailableONUOnly(oltIndex string, max int) ([]model.ONUStatus, error) {
 	}
 
 	cmd := fmt.Sprintf("show gpon onu state gpon-olt_1/%d/%d", slot, port)
-	output, err := RunTelnetCommand(cmd)
+	output, err := run(cmd)
 	if err != nil {
 		return nil, err
 	}
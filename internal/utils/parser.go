@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/achyar10/snmp-olt-zte/internal/model"
@@ -33,3 +34,107 @@ func ParseONULineOutput(output string) []model.ONUItem {
 	}
 	return results
 }
+
+// ParseONUEquipOutput parses the "show gpon remote-onu equip" output of the ONUs of a PON into their inventory by ONU
+// ID. Every ONU starts with its "ONU interface: gpon-onu_1/1/1:1" line, the attributes are "key: value" lines.
+func ParseONUEquipOutput(output string) map[int]model.ONUInventory {
+	results := make(map[int]model.ONUInventory)
+
+	onuID := 0
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		// Compare keys without case, spaces, dashes and underscores, e.g. "Vendor ID" and "VendorID"
+		key = strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(key))
+		if key == "onuinterface" || key == "interface" {
+			onuID = 0
+			if _, index, ok := strings.Cut(value, ":"); ok {
+				if id, err := strconv.Atoi(strings.TrimSpace(index)); err == nil {
+					onuID = id
+				}
+			}
+			continue
+		}
+		if onuID == 0 {
+			continue
+		}
+
+		inventory := results[onuID]
+		switch key {
+		case "vendorid":
+			inventory.VendorID = value
+		case "equipmentid":
+			inventory.EquipmentID = value
+		case "version", "hardwareversion", "hwversion":
+			inventory.HardwareVersion = value
+		case "activesoftwareversion", "activeswversion", "activeversion", "softwareversion", "swversion":
+			inventory.ActiveSoftwareVersion = value
+		case "standbysoftwareversion", "standbyswversion", "standbyversion":
+			inventory.StandbySoftwareVersion = value
+		case "mac", "macaddress", "onumac":
+			inventory.MacAddress = value
+		default:
+			continue
+		}
+		results[onuID] = inventory
+	}
+
+	return results
+}
+
+// ParseONUEthInterfaceOutput parses the "show gpon remote-onu interface eth" output of an ONU into its ETH UNI ports.
+// Every port starts with its "Interface: eth_0/1" line, e.g. followed by "Speed status: full-1000",
+// "Operate status: enable" and "Admin status: enable".
+func ParseONUEthInterfaceOutput(output string) []model.ONUUniPort {
+	results := make([]model.ONUUniPort, 0)
+
+	var port *model.ONUUniPort
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.ToLower(strings.TrimSpace(value))
+
+		if key == "interface" {
+			// The port number is the last number of the name, e.g. 1 of eth_0/1
+			name := strings.TrimSpace(line[strings.Index(line, ":")+1:])
+			id, err := strconv.Atoi(name[strings.LastIndexAny(name, "/_")+1:])
+			if err != nil {
+				port = nil
+				continue
+			}
+			results = append(results, model.ONUUniPort{Port: id, Name: name, Link: "unknown", AdminState: "unknown"})
+			port = &results[len(results)-1]
+			continue
+		}
+		if port == nil {
+			continue
+		}
+
+		switch key {
+		case "speed status":
+			// full-1000, half-100 or auto without link
+			if duplex, speed, ok := strings.Cut(value, "-"); ok {
+				port.Duplex, port.Speed = duplex, strings.ToUpper(speed)+"M"
+			}
+		case "operate status":
+			port.Link = map[string]string{"enable": "up", "disable": "down"}[value]
+			if port.Link == "" {
+				port.Link = "unknown"
+			}
+		case "admin status":
+			port.AdminState = map[string]string{"enable": "enabled", "disable": "disabled"}[value]
+			if port.AdminState == "" {
+				port.AdminState = "unknown"
+			}
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/achyar10/snmp-olt-zte/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseONUEquipOutput(t *testing.T) {
+	output := `show gpon remote-onu equip gpon-olt_1/1/1
+ONU interface:            gpon-onu_1/1/1:1
+Vendor ID:                ZTEG
+Version:                  V6.0
+EquipmentID:              F660
+Active software version:  V6.0.10P2N2
+Standby software version: V6.0.10P2N1
+MAC address:              00:1a:2b:3c:4d:5e
+
+ONU interface:            gpon-onu_1/1/1:3
+Vendor ID:                ZTEG
+EquipmentID:              F609
+ZXAN#`
+
+	inventory := ParseONUEquipOutput(output)
+	assert.Len(t, inventory, 2)
+	assert.Equal(t, model.ONUInventory{
+		VendorID:               "ZTEG",
+		EquipmentID:            "F660",
+		HardwareVersion:        "V6.0",
+		ActiveSoftwareVersion:  "V6.0.10P2N2",
+		StandbySoftwareVersion: "V6.0.10P2N1",
+		MacAddress:             "00:1a:2b:3c:4d:5e",
+	}, inventory[1])
+	assert.Equal(t, model.ONUInventory{VendorID: "ZTEG", EquipmentID: "F609"}, inventory[3])
+
+	assert.Empty(t, ParseONUEquipOutput("%Code 32310-GPONSRV : No related information to show."))
+}
+
+func TestParseONUEthInterfaceOutput(t *testing.T) {
+	output := `show gpon remote-onu interface eth gpon-onu_1/1/1:1
+Interface:                 eth_0/1
+Speed status:              full-1000
+Operate status:            enable
+Admin status:              enable
+PM status:                 disable
+Interface:                 eth_0/2
+Speed status:              auto
+Operate status:            disable
+Admin status:              disable
+ZXAN#`
+
+	assert.Equal(t, []model.ONUUniPort{
+		{Port: 1, Name: "eth_0/1", Link: "up", Speed: "1000M", Duplex: "full", AdminState: "enabled"},
+		{Port: 2, Name: "eth_0/2", Link: "down", AdminState: "disabled"},
+	}, ParseONUEthInterfaceOutput(output))
+
+	assert.Empty(t, ParseONUEthInterfaceOutput("%Code 32310-GPONSRV : No related information to show."))
+}
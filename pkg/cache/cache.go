@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrMiss is returned by Get when the key is not cached or has expired
+var ErrMiss = errors.New("cache miss")
+
+// Backend stores encoded values by key until their TTL expires
+type Backend interface {
+	Get(ctx context.Context, key string) ([]byte, error)                        // Get the value of a key, ErrMiss when not cached
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error // Set the value of a key for the TTL
+	Delete(ctx context.Context, key string) error                               // Delete a key, not an error when not cached
+}
+
+// Cache is a typed view of a backend, the values are encoded as JSON
+type Cache[T any] struct {
+	backend Backend
+}
+
+// New is a constructor function to create a typed cache of the values of type T stored in the backend
+func New[T any](backend Backend) Cache[T] {
+	return Cache[T]{backend: backend}
+}
+
+// Get returns the cached value of a key, ErrMiss when it is not cached
+func (c Cache[T]) Get(ctx context.Context, key string) (T, error) {
+	var value T
+
+	data, err := c.backend.Get(ctx, key)
+	if err != nil {
+		return value, err
+	}
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return value, err
+	}
+	return value, nil
+}
+
+// Set caches the value of a key for the TTL
+func (c Cache[T]) Set(ctx context.Context, key string, value T, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return c.backend.Set(ctx, key, data, ttl)
+}
+
+// Delete removes the cached value of a key
+func (c Cache[T]) Delete(ctx context.Context, key string) error {
+	return c.backend.Delete(ctx, key)
+}
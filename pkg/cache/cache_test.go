@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingBackend is a Backend of an unreachable server
+type failingBackend struct {
+	calls *int
+}
+
+var errUnreachable = errors.New("connection refused")
+
+func (b failingBackend) Get(context.Context, string) ([]byte, error) {
+	*b.calls++
+	return nil, errUnreachable
+}
+func (b failingBackend) Set(context.Context, string, []byte, time.Duration) error {
+	*b.calls++
+	return errUnreachable
+}
+func (b failingBackend) Delete(context.Context, string) error {
+	*b.calls++
+	return errUnreachable
+}
+
+type item struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestCacheTyped(t *testing.T) {
+	ctx := context.Background()
+	items := New[[]item](NewMemory(config.CacheConfig{}))
+
+	_, err := items.Get(ctx, "items")
+	assert.ErrorIs(t, err, ErrMiss)
+
+	assert.NoError(t, items.Set(ctx, "items", []item{{ID: 1, Name: "onu-1"}}, time.Minute))
+	value, err := items.Get(ctx, "items")
+	assert.NoError(t, err)
+	assert.Equal(t, []item{{ID: 1, Name: "onu-1"}}, value)
+
+	assert.NoError(t, items.Delete(ctx, "items"))
+	_, err = items.Get(ctx, "items")
+	assert.ErrorIs(t, err, ErrMiss)
+}
+
+func TestMemoryEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	memory := NewMemory(config.CacheConfig{MemoryMaxEntries: 2})
+
+	assert.NoError(t, memory.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, memory.Set(ctx, "b", []byte("2"), time.Minute))
+
+	// Reading a makes b the least recently used
+	_, err := memory.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.NoError(t, memory.Set(ctx, "c", []byte("3"), time.Minute))
+
+	assert.Equal(t, 2, memory.Len())
+	_, err = memory.Get(ctx, "b")
+	assert.ErrorIs(t, err, ErrMiss)
+	value, err := memory.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+}
+
+func TestMemoryExpires(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	memory := NewMemory(config.CacheConfig{})
+	memory.now = func() time.Time { return now }
+
+	assert.NoError(t, memory.Set(ctx, "a", []byte("1"), time.Minute))
+	now = now.Add(59 * time.Second)
+	_, err := memory.Get(ctx, "a")
+	assert.NoError(t, err)
+
+	now = now.Add(time.Second)
+	_, err = memory.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrMiss)
+	assert.Equal(t, 0, memory.Len())
+}
+
+func TestFallbackUsesMemoryWhilePrimaryIsDown(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	calls := 0
+	memory := NewMemory(config.CacheConfig{})
+	fallback := NewFallback(failingBackend{calls: &calls}, memory, config.CacheConfig{RetryInterval: 30})
+	fallback.now = func() time.Time { return now }
+
+	// The failed request is served by the memory cache
+	assert.NoError(t, fallback.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.False(t, fallback.Available())
+	assert.Equal(t, 1, calls)
+
+	// The primary is not tried again until the retry interval is over
+	value, err := fallback.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(30 * time.Second)
+	_, err = fallback.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestFallbackRecovers(t *testing.T) {
+	ctx := context.Background()
+	primary := NewMemory(config.CacheConfig{})
+	fallback := NewFallback(primary, NewMemory(config.CacheConfig{}), config.CacheConfig{})
+
+	// A miss of the primary is not a failure
+	_, err := fallback.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrMiss)
+	assert.True(t, fallback.Available())
+
+	fallback.down = true
+	assert.NoError(t, fallback.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.True(t, fallback.Available())
+	assert.Equal(t, 1, primary.Len())
+}
+
+func TestFallbackWithUnreachableRedis(t *testing.T) {
+	ctx := context.Background()
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: time.Second})
+	defer client.Close()
+	fallback := NewFallback(NewRedis(client), NewMemory(config.CacheConfig{}), config.CacheConfig{})
+
+	items := New[[]item](fallback)
+	assert.NoError(t, items.Set(ctx, "items", []item{{ID: 2}}, time.Minute))
+	assert.False(t, fallback.Available())
+
+	value, err := items.Get(ctx, "items")
+	assert.NoError(t, err)
+	assert.Equal(t, []item{{ID: 2}}, value)
+}
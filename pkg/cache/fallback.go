@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/rs/zerolog/log"
+)
+
+const defaultRetryInterval = 30 * time.Second // Default time before an unreachable primary backend is tried again
+
+// Fallback is a Backend using the primary backend, e.g. Redis, and the secondary backend, e.g. Memory, while the
+// primary is unreachable. After the retry interval the next request tries the primary again.
+type Fallback struct {
+	primary       Backend
+	secondary     Backend
+	retryInterval time.Duration
+	now           func() time.Time
+
+	mu      sync.Mutex
+	down    bool
+	retryAt time.Time
+}
+
+// NewFallback is a constructor function to create a cache falling back from the primary to the secondary backend
+func NewFallback(primary, secondary Backend, cfg config.CacheConfig) *Fallback {
+	retryInterval := time.Duration(cfg.RetryInterval) * time.Second
+	if retryInterval <= 0 {
+		retryInterval = defaultRetryInterval
+	}
+
+	return &Fallback{
+		primary:       primary,
+		secondary:     secondary,
+		retryInterval: retryInterval,
+		now:           time.Now,
+	}
+}
+
+// Get returns the value of a key from the primary backend, or from the secondary backend while the primary is down
+func (f *Fallback) Get(ctx context.Context, key string) ([]byte, error) {
+	if f.usePrimary() {
+		value, err := f.primary.Get(ctx, key)
+		if !f.failed(ctx, err) {
+			return value, err
+		}
+	}
+	return f.secondary.Get(ctx, key)
+}
+
+// Set stores the value of a key in the primary backend, or in the secondary backend while the primary is down
+func (f *Fallback) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if f.usePrimary() {
+		err := f.primary.Set(ctx, key, value, ttl)
+		if !f.failed(ctx, err) {
+			return err
+		}
+	}
+	return f.secondary.Set(ctx, key, value, ttl)
+}
+
+// Delete removes a key from both backends, the secondary may still have it from while the primary was down
+func (f *Fallback) Delete(ctx context.Context, key string) error {
+	if f.usePrimary() {
+		err := f.primary.Delete(ctx, key)
+		if !f.failed(ctx, err) && err != nil {
+			return err
+		}
+	}
+	return f.secondary.Delete(ctx, key)
+}
+
+// Available reports whether the primary backend is used
+func (f *Fallback) Available() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return !f.down
+}
+
+// usePrimary reports whether the primary backend is up or its retry interval is over
+func (f *Fallback) usePrimary() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return !f.down || !f.now().Before(f.retryAt)
+}
+
+// failed records the result of a request to the primary backend, it reports whether the primary is unreachable.
+// A miss is a result, a canceled request is not a failure of the primary.
+func (f *Fallback) failed(ctx context.Context, err error) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err != nil && !errors.Is(err, ErrMiss) && ctx.Err() == nil {
+		if !f.down {
+			log.Error().Err(err).Msg("Cache backend unreachable, using the in-memory cache")
+		}
+		f.down = true
+		f.retryAt = f.now().Add(f.retryInterval)
+		return true
+	}
+
+	if f.down && ctx.Err() == nil {
+		log.Info().Msg("Cache backend reachable again")
+		f.down = false
+	}
+	return false
+}
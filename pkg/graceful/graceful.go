@@ -16,7 +16,13 @@ func Shutdown(ctx context.Context, server *http.Server) error {
 	ch := make(chan error, 1)
 
 	go func() {
-		err := server.ListenAndServe()
+		var err error
+		if server.TLSConfig != nil {
+			// The certificate is already loaded in the TLS config
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			ch <- fmt.Errorf("failed to start server: %v", err)
 		}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/internal/utils"
+)
+
+const (
+	defaultPort              = "8081"            // Default port to listen on
+	defaultReadTimeout       = 15 * time.Second  // Default time to read a request including its body
+	defaultReadHeaderTimeout = 5 * time.Second   // Default time to read the request headers
+	defaultWriteTimeout      = 60 * time.Second  // Default time to write a response
+	defaultIdleTimeout       = 120 * time.Second // Default time a keep-alive connection may stay idle
+)
+
+// LoadServerConfig is a function to get the HTTP server configuration
+func LoadServerConfig(cfg *config.Config) config.ServerConfig {
+	// Check if the application is running in development or production environment
+	if os.Getenv("APP_ENV") == "development" || os.Getenv("APP_ENV") == "production" {
+		return config.ServerConfig{
+			Host:              os.Getenv("SERVER_HOST"),
+			Port:              os.Getenv("SERVER_PORT"),
+			ReadTimeout:       utils.ConvertStringToInteger(os.Getenv("SERVER_READ_TIMEOUT")),
+			ReadHeaderTimeout: utils.ConvertStringToInteger(os.Getenv("SERVER_READ_HEADER_TIMEOUT")),
+			WriteTimeout:      utils.ConvertStringToInteger(os.Getenv("SERVER_WRITE_TIMEOUT")),
+			IdleTimeout:       utils.ConvertStringToInteger(os.Getenv("SERVER_IDLE_TIMEOUT")),
+			TLSCertFile:       os.Getenv("SERVER_TLS_CERT_FILE"),
+			TLSKeyFile:        os.Getenv("SERVER_TLS_KEY_FILE"),
+			TLSClientCAFile:   os.Getenv("SERVER_TLS_CLIENT_CA_FILE"),
+		}
+	}
+
+	return cfg.ServerCfg
+}
+
+// NewServer is a constructor function to create an HTTP server serving handler.
+// The server has a TLS config holding the certificate when one is configured, and then serves HTTPS.
+func NewServer(cfg config.ServerConfig, handler http.Handler) (*http.Server, error) {
+	port := cfg.Port
+	if port == "" {
+		port = defaultPort
+	}
+
+	server := &http.Server{
+		Addr:              net.JoinHostPort(cfg.Host, port),
+		Handler:           handler,
+		ReadTimeout:       secondsOrDefault(cfg.ReadTimeout, defaultReadTimeout),
+		ReadHeaderTimeout: secondsOrDefault(cfg.ReadHeaderTimeout, defaultReadHeaderTimeout),
+		WriteTimeout:      secondsOrDefault(cfg.WriteTimeout, defaultWriteTimeout),
+		IdleTimeout:       secondsOrDefault(cfg.IdleTimeout, defaultIdleTimeout),
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	server.TLSConfig = tlsConfig
+
+	return server, nil
+}
+
+// newTLSConfig is a function to build the TLS config of the server, nil when no certificate is configured
+func newTLSConfig(cfg config.ServerConfig) (*tls.Config, error) {
+	if cfg.TLSCertFile == "" && cfg.TLSKeyFile == "" {
+		if cfg.TLSClientCAFile != "" {
+			return nil, fmt.Errorf("TLS client CA requires a TLS certificate and key")
+		}
+		return nil, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+	}
+
+	// Require client certificates signed by the client CA
+	if cfg.TLSClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.TLSClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS client CA: %w", err)
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in TLS client CA %s", cfg.TLSClientCAFile)
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// secondsOrDefault returns the duration of the given seconds, or the default if it is not set
+func secondsOrDefault(seconds int, defaultValue time.Duration) time.Duration {
+	if seconds <= 0 {
+		return defaultValue
+	}
+	return time.Duration(seconds) * time.Second
+}
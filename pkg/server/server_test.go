@@ -0,0 +1,82 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestCertificate writes a self-signed certificate and its key, and returns their paths
+func writeTestCertificate(t *testing.T) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return certFile, keyFile
+}
+
+func TestNewServerDefaults(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{}, http.NotFoundHandler())
+	assert.NoError(t, err)
+	assert.Equal(t, ":8081", server.Addr)
+	assert.Equal(t, defaultReadTimeout, server.ReadTimeout)
+	assert.Equal(t, defaultReadHeaderTimeout, server.ReadHeaderTimeout)
+	assert.Equal(t, defaultWriteTimeout, server.WriteTimeout)
+	assert.Equal(t, defaultIdleTimeout, server.IdleTimeout)
+	assert.Nil(t, server.TLSConfig)
+
+	server, err = NewServer(config.ServerConfig{Host: "127.0.0.1", Port: "9000", WriteTimeout: 30}, http.NotFoundHandler())
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9000", server.Addr)
+	assert.Equal(t, 30*time.Second, server.WriteTimeout)
+}
+
+func TestNewServerTLS(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+
+	server, err := NewServer(config.ServerConfig{TLSCertFile: certFile, TLSKeyFile: keyFile}, http.NotFoundHandler())
+	assert.NoError(t, err)
+	assert.Len(t, server.TLSConfig.Certificates, 1)
+	assert.Equal(t, tls.NoClientCert, server.TLSConfig.ClientAuth)
+
+	// The self-signed certificate is its own client CA
+	server, err = NewServer(config.ServerConfig{TLSCertFile: certFile, TLSKeyFile: keyFile, TLSClientCAFile: certFile}, http.NotFoundHandler())
+	assert.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, server.TLSConfig.ClientAuth)
+
+	_, err = NewServer(config.ServerConfig{TLSClientCAFile: certFile}, http.NotFoundHandler())
+	assert.Error(t, err)
+	_, err = NewServer(config.ServerConfig{TLSCertFile: certFile, TLSKeyFile: keyFile, TLSClientCAFile: keyFile}, http.NotFoundHandler())
+	assert.Error(t, err)
+	_, err = NewServer(config.ServerConfig{TLSCertFile: certFile}, http.NotFoundHandler())
+	assert.Error(t, err)
+}
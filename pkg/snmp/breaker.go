@@ -0,0 +1,182 @@
+package snmp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+)
+
+// BreakerState is the state of a circuit breaker
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"    // Requests are sent to the agent
+	BreakerOpen     BreakerState = "open"      // Requests fail fast until the cooldown is over
+	BreakerHalfOpen BreakerState = "half-open" // A probe is checking if the agent is reachable again
+)
+
+const (
+	defaultBreakerThreshold   = 5               // Default number of consecutive timeouts before the breaker opens
+	defaultBreakerCooldown    = 5 * time.Second // Default time the breaker stays open before probing
+	defaultBreakerMaxCooldown = 5 * time.Minute // Default maximum cooldown after repeated failed probes
+)
+
+var ErrCircuitOpen = errors.New("SNMP agent unreachable, circuit breaker is open")
+
+// Breaker is a circuit breaker for one SNMP target.
+// It opens after consecutive timeouts and fails fast while open. After the cooldown the next request
+// probes the agent, closing the breaker when the probe succeeds or doubling the cooldown when it fails.
+type Breaker struct {
+	threshold   int                             // Consecutive timeouts before the breaker opens
+	cooldown    time.Duration                   // Cooldown after the breaker opens
+	maxCooldown time.Duration                   // Maximum cooldown after repeated failed probes
+	probe       func(ctx context.Context) error // Lightweight request checking if the agent is reachable
+	now         func() time.Time
+
+	mu                  sync.Mutex
+	state               BreakerState
+	consecutiveTimeouts int
+	trips               int
+	currentCooldown     time.Duration
+	openedAt            time.Time
+	retryAt             time.Time
+}
+
+// BreakerStats is a snapshot of the circuit breaker state
+type BreakerStats struct {
+	State               BreakerState
+	ConsecutiveTimeouts int
+	Trips               int           // Number of times the breaker opened
+	Cooldown            time.Duration // Current cooldown
+	OpenedAt            time.Time     // Zero when the breaker is closed
+	RetryAt             time.Time     // Zero when the breaker is closed
+}
+
+// NewBreaker is a constructor function to create a new circuit breaker, probe is called to check the agent when half-open
+func NewBreaker(cfg config.SnmpConfig, probe func(ctx context.Context) error) *Breaker {
+	cooldown := secondsOrDefault(cfg.BreakerCooldown, defaultBreakerCooldown)
+
+	return &Breaker{
+		threshold:       intOrDefault(cfg.BreakerThreshold, defaultBreakerThreshold),
+		cooldown:        cooldown,
+		maxCooldown:     max(secondsOrDefault(cfg.BreakerMaxCooldown, defaultBreakerMaxCooldown), cooldown),
+		probe:           probe,
+		now:             time.Now,
+		state:           BreakerClosed,
+		currentCooldown: cooldown,
+	}
+}
+
+// Do runs fn when the breaker allows it and records its result, it returns ErrCircuitOpen while the breaker is open
+func (b *Breaker) Do(ctx context.Context, fn func() error) error {
+	if err := b.allow(ctx); err != nil {
+		return err
+	}
+
+	err := fn()
+	b.record(err)
+	return err
+}
+
+// Stats returns a snapshot of the breaker state
+func (b *Breaker) Stats() BreakerStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := BreakerStats{
+		State:               b.state,
+		ConsecutiveTimeouts: b.consecutiveTimeouts,
+		Trips:               b.trips,
+		Cooldown:            b.currentCooldown,
+	}
+	if b.state != BreakerClosed {
+		stats.OpenedAt = b.openedAt
+		stats.RetryAt = b.retryAt
+	}
+	return stats
+}
+
+// allow checks if a request may be sent, probing the agent when the cooldown is over
+func (b *Breaker) allow(ctx context.Context) error {
+	b.mu.Lock()
+	switch b.state {
+	case BreakerClosed:
+		b.mu.Unlock()
+		return nil
+	case BreakerOpen:
+		if b.now().Before(b.retryAt) {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		// Only this request probes, the others fail fast until the probe is done
+		b.state = BreakerHalfOpen
+		b.mu.Unlock()
+	default:
+		b.mu.Unlock()
+		return ErrCircuitOpen
+	}
+
+	// A client disconnect must not fail the probe
+	err := b.probe(context.WithoutCancel(ctx))
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err != nil {
+		b.currentCooldown = min(b.currentCooldown*2, b.maxCooldown)
+		b.retryAt = b.now().Add(b.currentCooldown)
+		b.state = BreakerOpen
+		return ErrCircuitOpen
+	}
+
+	b.state = BreakerClosed
+	b.consecutiveTimeouts = 0
+	b.currentCooldown = b.cooldown
+	return nil
+}
+
+// record counts consecutive timeouts and opens the breaker when the threshold is reached
+func (b *Breaker) record(err error) {
+	// A canceled request, an expired request deadline or a busy pool says nothing about the agent
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, ErrPoolTimeout) || errors.Is(err, ErrPoolClosed) || errors.Is(err, ErrLimiterQueueFull) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !IsTimeout(err) {
+		// The agent answered
+		b.consecutiveTimeouts = 0
+		return
+	}
+
+	b.consecutiveTimeouts++
+	if b.state == BreakerClosed && b.consecutiveTimeouts >= b.threshold {
+		b.state = BreakerOpen
+		b.trips++
+		b.openedAt = b.now()
+		b.retryAt = b.openedAt.Add(b.currentCooldown)
+	}
+}
+
+// IsTimeout checks if an SNMP request failed because the agent did not answer in time
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	// gosnmp reports exhausted retries as a plain "request timeout" error
+	return strings.Contains(err.Error(), "request timeout")
+}
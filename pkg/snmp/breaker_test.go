@@ -0,0 +1,106 @@
+package snmp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/stretchr/testify/assert"
+)
+
+var errRequestTimeout = errors.New("request timeout (after 1 retries)")
+
+// newTestBreaker creates a breaker with a fake clock, the probe fails while probeErr is set
+func newTestBreaker(probeErr *error) (*Breaker, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewBreaker(config.SnmpConfig{BreakerThreshold: 2, BreakerCooldown: 5, BreakerMaxCooldown: 15},
+		func(context.Context) error { return *probeErr })
+	breaker.now = func() time.Time { return now }
+	return breaker, &now
+}
+
+func TestBreakerOpensAfterConsecutiveTimeouts(t *testing.T) {
+	var probeErr error
+	breaker, _ := newTestBreaker(&probeErr)
+	ctx := context.Background()
+
+	fail := func() error { return errRequestTimeout }
+
+	assert.ErrorIs(t, breaker.Do(ctx, fail), errRequestTimeout)
+	assert.NoError(t, breaker.Do(ctx, func() error { return nil })) // An answer resets the count
+	assert.ErrorIs(t, breaker.Do(ctx, fail), errRequestTimeout)
+	assert.Equal(t, BreakerClosed, breaker.Stats().State)
+
+	assert.ErrorIs(t, breaker.Do(ctx, fail), errRequestTimeout)
+	assert.Equal(t, BreakerOpen, breaker.Stats().State)
+	assert.Equal(t, 1, breaker.Stats().Trips)
+
+	called := false
+	err := breaker.Do(ctx, func() error { called = true; return nil })
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.False(t, called)
+}
+
+func TestBreakerIgnoresCanceledRequests(t *testing.T) {
+	var probeErr error
+	breaker, _ := newTestBreaker(&probeErr)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_ = breaker.Do(ctx, func() error { return context.Canceled })
+		_ = breaker.Do(ctx, func() error { return ErrPoolTimeout })
+	}
+	assert.Equal(t, BreakerClosed, breaker.Stats().State)
+	assert.Equal(t, 0, breaker.Stats().ConsecutiveTimeouts)
+}
+
+func TestBreakerProbesAfterCooldown(t *testing.T) {
+	probeErr := errRequestTimeout
+	breaker, now := newTestBreaker(&probeErr)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_ = breaker.Do(ctx, func() error { return errRequestTimeout })
+	}
+	assert.Equal(t, BreakerOpen, breaker.Stats().State)
+
+	// A failed probe doubles the cooldown, up to the maximum
+	*now = now.Add(5 * time.Second)
+	assert.ErrorIs(t, breaker.Do(ctx, func() error { return nil }), ErrCircuitOpen)
+	assert.Equal(t, 10*time.Second, breaker.Stats().Cooldown)
+
+	*now = now.Add(10 * time.Second)
+	assert.ErrorIs(t, breaker.Do(ctx, func() error { return nil }), ErrCircuitOpen)
+	assert.Equal(t, 15*time.Second, breaker.Stats().Cooldown)
+
+	// Still open before the cooldown is over
+	*now = now.Add(10 * time.Second)
+	probeErr = nil
+	assert.ErrorIs(t, breaker.Do(ctx, func() error { return nil }), ErrCircuitOpen)
+
+	// A successful probe closes the breaker and resets the cooldown
+	*now = now.Add(5 * time.Second)
+	assert.NoError(t, breaker.Do(ctx, func() error { return nil }))
+	stats := breaker.Stats()
+	assert.Equal(t, BreakerClosed, stats.State)
+	assert.Equal(t, 5*time.Second, stats.Cooldown)
+	assert.True(t, stats.OpenedAt.IsZero())
+}
+
+func TestIsTimeout(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{errRequestTimeout, true},
+		{errors.New("SNMP Get failed: request timeout (after 3 retries)"), true},
+		{errors.New("noSuchName"), false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, IsTimeout(tc.err))
+	}
+}
@@ -0,0 +1,171 @@
+package snmp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+)
+
+var ErrLimiterQueueFull = errors.New("too many SNMP requests queued for the target")
+
+// Limiter bounds the load put on one SNMP target.
+// It caps the number of requests in flight, queueing the others, and the number of PDUs sent per second.
+type Limiter struct {
+	slots    chan struct{} // One token per request in flight
+	maxQueue int           // Max requests waiting for a slot, 0 is unlimited
+
+	mu           sync.Mutex
+	rate         float64   // PDUs per second, 0 is unlimited
+	burst        float64   // PDUs that may be sent at once
+	tokens       float64   // Available PDUs, negative when PDUs are waiting
+	lastRefill   time.Time // Last time tokens were added
+	queued       int       // Requests waiting for a slot
+	requests     int64     // Requests that got a slot
+	waited       int64     // Requests that had to wait for a slot
+	rejected     int64     // Requests rejected because the queue is full
+	queueTime    time.Duration
+	maxQueueTime time.Duration
+	pdus         int64 // PDUs sent
+	rateWaited   int64 // PDUs that had to wait for the rate cap
+	rateWait     time.Duration
+}
+
+// LimiterStats is a snapshot of the limiter state and queue metrics
+type LimiterStats struct {
+	MaxInFlight      int
+	InFlight         int
+	MaxQueue         int
+	Queued           int
+	MaxPDUsPerSecond float64
+	Requests         int64         // Requests that got a slot
+	Waited           int64         // Requests that had to wait for a slot
+	Rejected         int64         // Requests rejected because the queue is full
+	QueueTime        time.Duration // Total time requests waited for a slot
+	MaxQueueTime     time.Duration // Longest time a request waited for a slot
+	PDUs             int64         // PDUs sent
+	RateWaited       int64         // PDUs delayed by the rate cap
+	RateWait         time.Duration // Total time PDUs were delayed by the rate cap
+}
+
+// NewLimiter is a constructor function to create a new limiter, maxInFlight is used when the config does not set it
+func NewLimiter(cfg config.SnmpConfig, maxInFlight int) *Limiter {
+	maxInFlight = intOrDefault(cfg.MaxInFlight, maxInFlight)
+	rate := float64(max(cfg.MaxPDUsPerSecond, 0))
+
+	return &Limiter{
+		slots:      make(chan struct{}, maxInFlight),
+		maxQueue:   max(cfg.MaxQueue, 0),
+		rate:       rate,
+		burst:      max(rate, 1),
+		tokens:     max(rate, 1),
+		lastRefill: time.Now(),
+	}
+}
+
+// Acquire waits for a slot for one request, queueing while all slots are in use.
+// The returned function releases the slot and must be called once the request is done.
+func (l *Limiter) Acquire(ctx context.Context) (func(), error) {
+	release := func() { <-l.slots }
+
+	// Take a free slot without queueing
+	select {
+	case l.slots <- struct{}{}:
+		l.mu.Lock()
+		l.requests++
+		l.mu.Unlock()
+		return release, nil
+	default:
+	}
+
+	l.mu.Lock()
+	if l.maxQueue > 0 && l.queued >= l.maxQueue {
+		l.rejected++
+		l.mu.Unlock()
+		return nil, ErrLimiterQueueFull
+	}
+	l.queued++
+	l.mu.Unlock()
+
+	start := time.Now()
+	var err error
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	waited := time.Since(start)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.queued--
+	l.queueTime += waited
+	l.maxQueueTime = max(l.maxQueueTime, waited)
+	if err != nil {
+		return nil, err
+	}
+	l.requests++
+	l.waited++
+	return release, nil
+}
+
+// Wait waits until one more PDU may be sent under the rate cap
+func (l *Limiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	l.pdus++
+	if l.rate <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+
+	// Refill the tokens since the last PDU and take one, waiting for it when there are none left
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.lastRefill).Seconds()*l.rate)
+	l.lastRefill = now
+	l.tokens--
+
+	if l.tokens >= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.rateWaited++
+	l.rateWait += delay
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the limiter state and queue metrics
+func (l *Limiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return LimiterStats{
+		MaxInFlight:      cap(l.slots),
+		InFlight:         len(l.slots),
+		MaxQueue:         l.maxQueue,
+		Queued:           l.queued,
+		MaxPDUsPerSecond: l.rate,
+		Requests:         l.requests,
+		Waited:           l.waited,
+		Rejected:         l.rejected,
+		QueueTime:        l.queueTime,
+		MaxQueueTime:     l.maxQueueTime,
+		PDUs:             l.pdus,
+		RateWaited:       l.rateWaited,
+		RateWait:         l.rateWait,
+	}
+}
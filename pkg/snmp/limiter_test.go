@@ -0,0 +1,83 @@
+package snmp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiterQueuesRequests(t *testing.T) {
+	limiter := NewLimiter(config.SnmpConfig{MaxInFlight: 1}, 4)
+	ctx := context.Background()
+
+	release, err := limiter.Acquire(ctx)
+	assert.NoError(t, err)
+
+	// The second request waits until the first one releases its slot
+	time.AfterFunc(50*time.Millisecond, release)
+	release, err = limiter.Acquire(ctx)
+	assert.NoError(t, err)
+	release()
+
+	stats := limiter.Stats()
+	assert.Equal(t, 1, stats.MaxInFlight)
+	assert.Equal(t, 0, stats.InFlight)
+	assert.Equal(t, int64(2), stats.Requests)
+	assert.Equal(t, int64(1), stats.Waited)
+	assert.GreaterOrEqual(t, stats.MaxQueueTime, 40*time.Millisecond)
+}
+
+func TestLimiterRejectsWhenQueueIsFull(t *testing.T) {
+	limiter := NewLimiter(config.SnmpConfig{MaxInFlight: 1, MaxQueue: 1}, 4)
+
+	release, err := limiter.Acquire(context.Background())
+	assert.NoError(t, err)
+	defer release()
+
+	// Fill the queue with one waiting request
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := limiter.Acquire(ctx)
+		done <- err
+	}()
+	assert.Eventually(t, func() bool { return limiter.Stats().Queued == 1 }, time.Second, time.Millisecond)
+
+	_, err = limiter.Acquire(context.Background())
+	assert.ErrorIs(t, err, ErrLimiterQueueFull)
+	assert.Equal(t, int64(1), limiter.Stats().Rejected)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, 0, limiter.Stats().Queued)
+}
+
+func TestLimiterRateCap(t *testing.T) {
+	limiter := NewLimiter(config.SnmpConfig{MaxPDUsPerSecond: 20}, 4)
+	ctx := context.Background()
+
+	// The burst of 20 PDUs is sent at once, the next 5 PDUs are spaced at 50ms
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		assert.NoError(t, limiter.Wait(ctx))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+
+	stats := limiter.Stats()
+	assert.Equal(t, int64(25), stats.PDUs)
+	assert.Equal(t, int64(5), stats.RateWaited)
+}
+
+func TestLimiterWithoutRateCap(t *testing.T) {
+	limiter := NewLimiter(config.SnmpConfig{}, 4)
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, limiter.Wait(context.Background()))
+	}
+	stats := limiter.Stats()
+	assert.Equal(t, 4, stats.MaxInFlight)
+	assert.Equal(t, int64(0), stats.RateWaited)
+}
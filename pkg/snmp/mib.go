@@ -0,0 +1,22 @@
+package snmp
+
+// SNMPv2-MIB system group scalars, SysUpTimeOID is defined with the session health check
+const (
+	SysDescrOID = ".1.3.6.1.2.1.1.1.0" // sysDescr: model and software version of the agent
+	SysNameOID  = ".1.3.6.1.2.1.1.5.0" // sysName: host name of the agent
+)
+
+// IF-MIB columns, the interface ifIndex is appended per interface
+const (
+	IfAdminStatusOID = ".1.3.6.1.2.1.2.2.1.7"  // ifAdminStatus: up(1), down(2), testing(3)
+	IfOperStatusOID  = ".1.3.6.1.2.1.2.2.1.8"  // ifOperStatus: up(1), down(2), testing(3), unknown(4), dormant(5), ...
+	IfInDiscardsOID  = ".1.3.6.1.2.1.2.2.1.13" // ifInDiscards: Counter32
+	IfInErrorsOID    = ".1.3.6.1.2.1.2.2.1.14" // ifInErrors: Counter32
+	IfOutDiscardsOID = ".1.3.6.1.2.1.2.2.1.19" // ifOutDiscards: Counter32
+	IfOutErrorsOID   = ".1.3.6.1.2.1.2.2.1.20" // ifOutErrors: Counter32
+
+	IfNameOID        = ".1.3.6.1.2.1.31.1.1.1.1"  // ifName: CLI name of the interface, e.g. xgei_1/3/1
+	IfHCInOctetsOID  = ".1.3.6.1.2.1.31.1.1.1.6"  // ifHCInOctets: Counter64
+	IfHCOutOctetsOID = ".1.3.6.1.2.1.31.1.1.1.10" // ifHCOutOctets: Counter64
+	IfHighSpeedOID   = ".1.3.6.1.2.1.31.1.1.1.15" // ifHighSpeed: Gauge32 in Mbit/s
+)
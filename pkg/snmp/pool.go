@@ -0,0 +1,285 @@
+package snmp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/gosnmp/gosnmp"
+)
+
+// SysUpTimeOID is the lightweight OID used to check if an SNMP agent is responding
+const SysUpTimeOID = ".1.3.6.1.2.1.1.3.0"
+
+const (
+	defaultPoolSize            = 4                // Default number of sessions per target
+	defaultPoolTimeout         = 10 * time.Second // Default time to wait for a free session
+	defaultTimeout             = 3 * time.Second  // Default SNMP request timeout
+	defaultRetries             = 1                // Default number of retries for SNMP requests
+	defaultHealthCheckInterval = time.Minute      // Default idle time before a session is health checked
+)
+
+var (
+	ErrPoolTimeout = errors.New("timeout waiting for a free SNMP session")
+	ErrPoolClosed  = errors.New("SNMP session pool is closed")
+)
+
+// Pool is a bounded pool of connected SNMP sessions to one target.
+// A gosnmp session is not safe for concurrent use, so each session is used by one request at a time.
+type Pool struct {
+	cfg                 config.SnmpConfig
+	timeout             time.Duration // SNMP request timeout
+	retries             int           // Number of retries for SNMP requests
+	poolTimeout         time.Duration // Time to wait for a free session
+	healthCheckInterval time.Duration // Idle time before a session is health checked
+
+	idle    chan *session // Idle connected sessions
+	slots   chan struct{} // One token per open session, bounds the pool size
+	limiter *Limiter      // Bounds the requests in flight and the PDUs per second to the target
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// session is a connected SNMP session with the time it was last used
+type session struct {
+	snmp     *gosnmp.GoSNMP
+	lastUsed time.Time
+}
+
+// NewPool is a constructor function to create a new SNMP session pool, sessions are connected lazily
+func NewPool(cfg config.SnmpConfig) *Pool {
+	poolSize := cfg.PoolSize
+	if poolSize <= 0 {
+		poolSize = defaultPoolSize
+	}
+
+	return &Pool{
+		cfg:                 cfg,
+		timeout:             secondsOrDefault(cfg.Timeout, defaultTimeout),
+		retries:             retriesOrDefault(cfg.Retries),
+		poolTimeout:         secondsOrDefault(cfg.PoolTimeout, defaultPoolTimeout),
+		healthCheckInterval: secondsOrDefault(cfg.HealthCheckInterval, defaultHealthCheckInterval),
+		idle:                make(chan *session, poolSize),
+		slots:               make(chan struct{}, poolSize),
+		limiter:             NewLimiter(cfg, poolSize),
+	}
+}
+
+// Limiter returns the limiter of the target, to read its queue metrics
+func (p *Pool) Limiter() *Limiter {
+	return p.limiter
+}
+
+// Do runs fn with a session of the pool, the session aborts its requests when ctx is done.
+// The session is closed instead of being returned to the pool when fn fails with a network error or a timeout, so it
+// reconnects on the next use. Error statuses of an agent that answered keep the session.
+func (p *Pool) Do(ctx context.Context, fn func(snmp *gosnmp.GoSNMP) error) error {
+	// Wait in the limiter queue for a request slot, up to the pool timeout
+	waitCtx, cancel := context.WithTimeout(ctx, p.poolTimeout)
+	releaseSlot, err := p.limiter.Acquire(waitCtx)
+	cancel()
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			return ErrPoolTimeout
+		}
+		return err
+	}
+	defer releaseSlot()
+
+	s, err := p.acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	// gosnmp only checks the context between retries, so unblock a pending read as soon as ctx is done
+	stop := context.AfterFunc(ctx, func() {
+		if s.snmp.Conn != nil {
+			_ = s.snmp.Conn.SetDeadline(time.Now())
+		}
+	})
+
+	s.snmp.Context = ctx
+	err = fn(s.snmp)
+	s.snmp.Context = context.Background() // Do not keep the request context on an idle session
+	stop()
+	p.release(s, sessionBroken(err))
+	return err
+}
+
+// Close closes all idle sessions, sessions in use are closed when they are released
+func (p *Pool) Close() {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	for {
+		select {
+		case s := <-p.idle:
+			p.discard(s)
+		default:
+			return
+		}
+	}
+}
+
+// acquire returns a healthy idle session or connects a new one, waiting up to the pool timeout or until ctx is done
+func (p *Pool) acquire(ctx context.Context) (*session, error) {
+	timer := time.NewTimer(p.poolTimeout)
+	defer timer.Stop()
+
+	for {
+		if p.isClosed() {
+			return nil, ErrPoolClosed
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Prefer an idle session over opening a new one
+		select {
+		case s := <-p.idle:
+			if p.healthy(ctx, s) {
+				return s, nil
+			}
+			p.discard(s)
+			continue
+		default:
+		}
+
+		select {
+		case s := <-p.idle:
+			if p.healthy(ctx, s) {
+				return s, nil
+			}
+			p.discard(s)
+		case p.slots <- struct{}{}:
+			s, err := p.connect()
+			if err != nil {
+				<-p.slots
+				return nil, err
+			}
+			return s, nil
+		case <-timer.C:
+			return nil, ErrPoolTimeout
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// release returns a session to the pool, or closes it if it is broken or the pool is closed
+func (p *Pool) release(s *session, broken bool) {
+	if broken || p.isClosed() {
+		p.discard(s)
+		return
+	}
+
+	s.lastUsed = time.Now()
+	p.idle <- s
+}
+
+// connect opens a new session to the target
+func (p *Pool) connect() (*session, error) {
+	target, err := NewSnmpTarget(p.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("SNMP config error: %w", err)
+	}
+	target.Timeout = p.timeout
+	target.Retries = p.retries
+	target.PreSend = p.preSend
+
+	if err := target.Connect(); err != nil {
+		return nil, fmt.Errorf("SNMP Connect error: %w", err)
+	}
+
+	return &session{snmp: target, lastUsed: time.Now()}, nil
+}
+
+// preSend delays each PDU under the rate cap of the limiter.
+// gosnmp sets the request deadline before calling it, so the deadline is restarted after a delay.
+func (p *Pool) preSend(x *gosnmp.GoSNMP) {
+	start := time.Now()
+	if err := p.limiter.Wait(x.Context); err != nil || time.Since(start) < time.Millisecond {
+		return
+	}
+
+	deadline := time.Now().Add(x.Timeout)
+	if ctxDeadline, ok := x.Context.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	_ = x.Conn.SetDeadline(deadline)
+
+	// Do not undo the expired deadline set when the request was canceled meanwhile
+	if x.Context.Err() != nil {
+		_ = x.Conn.SetDeadline(time.Now())
+	}
+}
+
+// healthy checks a session that has been idle longer than the health check interval with a sysUpTime Get.
+// The check runs in the limiter slot of the request and its PDU waits for the rate cap like the request PDUs.
+func (p *Pool) healthy(ctx context.Context, s *session) bool {
+	if time.Since(s.lastUsed) < p.healthCheckInterval {
+		return true
+	}
+
+	s.snmp.Context = ctx
+	_, err := s.snmp.Get([]string{SysUpTimeOID})
+	s.snmp.Context = context.Background()
+	return err == nil
+}
+
+// sessionBroken checks if a request failed in a way that leaves the session unusable: a network error, a timeout, a
+// read interrupted by ctx or an SNMPv3 engine that has to be discovered again
+func sessionBroken(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || IsTimeout(err) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, gosnmp.ErrNotInTimeWindow) || errors.Is(err, gosnmp.ErrUnknownEngineID)
+}
+
+// discard closes a session and frees its slot
+func (p *Pool) discard(s *session) {
+	if s.snmp.Conn != nil {
+		_ = s.snmp.Conn.Close()
+	}
+	<-p.slots
+}
+
+func (p *Pool) isClosed() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.closed
+}
+
+// secondsOrDefault converts a number of seconds to a duration, using the default if it is not set
+func secondsOrDefault(seconds int, defaultValue time.Duration) time.Duration {
+	if seconds <= 0 {
+		return defaultValue
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// retriesOrDefault returns the configured number of retries, or the default if it is not set
+func retriesOrDefault(retries *int) int {
+	if retries == nil {
+		return defaultRetries
+	}
+	return max(*retries, 0)
+}
+
+// intOrDefault returns the value, or the default if it is not set
+func intOrDefault(value, defaultValue int) int {
+	if value <= 0 {
+		return defaultValue
+	}
+	return value
+}
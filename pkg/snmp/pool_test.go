@@ -0,0 +1,188 @@
+package snmp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/achyar10/snmp-olt-zte/pkg/snmpsim"
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPool(poolSize int) *Pool {
+	return NewPool(config.SnmpConfig{
+		Ip: "127.0.0.1", Port: 161, Community: "public", PoolSize: poolSize, PoolTimeout: 1,
+	})
+}
+
+func TestPoolReusesSession(t *testing.T) {
+	pool := newTestPool(1)
+	defer pool.Close()
+
+	var first, second *gosnmp.GoSNMP
+	assert.NoError(t, pool.Do(context.Background(), func(snmp *gosnmp.GoSNMP) error { first = snmp; return nil }))
+	assert.NoError(t, pool.Do(context.Background(), func(snmp *gosnmp.GoSNMP) error { second = snmp; return nil }))
+	assert.Same(t, first, second)
+}
+
+func TestPoolReconnectsBrokenSession(t *testing.T) {
+	pool := newTestPool(1)
+	defer pool.Close()
+
+	var first, second *gosnmp.GoSNMP
+	err := pool.Do(context.Background(), func(snmp *gosnmp.GoSNMP) error { first = snmp; return errors.New("request timeout") })
+	assert.Error(t, err)
+	assert.NoError(t, pool.Do(context.Background(), func(snmp *gosnmp.GoSNMP) error { second = snmp; return nil }))
+	assert.NotSame(t, first, second)
+}
+
+func TestPoolKeepsSessionOnErrorStatus(t *testing.T) {
+	pool := newTestPool(1)
+	defer pool.Close()
+
+	// The agent answered, e.g. with noSuchName, so the session is still connected
+	var first, second *gosnmp.GoSNMP
+	err := pool.Do(context.Background(), func(snmp *gosnmp.GoSNMP) error {
+		first = snmp
+		return errors.New("SNMP error status NoSuchName for OID .1.3.6.1.2.1.1.3.0")
+	})
+	assert.Error(t, err)
+	assert.NoError(t, pool.Do(context.Background(), func(snmp *gosnmp.GoSNMP) error { second = snmp; return nil }))
+	assert.Same(t, first, second)
+}
+
+func TestSessionBroken(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "no error"},
+		{name: "error status", err: errors.New("SNMP error status TooBig")},
+		{name: "walk callback error", err: errors.New("invalid ONU ID")},
+		{name: "exhausted retries", err: errors.New("request timeout (after 1 retries)"), want: true},
+		{name: "network error", err: &net.OpError{Op: "read", Err: errors.New("connection refused")}, want: true},
+		{name: "canceled", err: context.Canceled, want: true},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, want: true},
+		{name: "SNMPv3 time window", err: gosnmp.ErrNotInTimeWindow, want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, sessionBroken(tc.err))
+		})
+	}
+}
+
+func TestPoolIsBounded(t *testing.T) {
+	pool := newTestPool(1)
+	defer pool.Close()
+
+	err := pool.Do(context.Background(), func(*gosnmp.GoSNMP) error {
+		// The only session is in use, so a nested request must time out
+		return pool.Do(context.Background(), func(*gosnmp.GoSNMP) error { return nil })
+	})
+	assert.ErrorIs(t, err, ErrPoolTimeout)
+}
+
+func TestPoolClosed(t *testing.T) {
+	pool := newTestPool(1)
+	pool.Close()
+
+	err := pool.Do(context.Background(), func(*gosnmp.GoSNMP) error { return nil })
+	assert.ErrorIs(t, err, ErrPoolClosed)
+}
+
+func TestPoolCanceledContext(t *testing.T) {
+	pool := newTestPool(1)
+	defer pool.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	err := pool.Do(ctx, func(*gosnmp.GoSNMP) error { called = true; return nil })
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, called)
+}
+
+func TestPoolSetsSessionContext(t *testing.T) {
+	pool := newTestPool(1)
+	defer pool.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var session *gosnmp.GoSNMP
+	err := pool.Do(ctx, func(snmp *gosnmp.GoSNMP) error {
+		session = snmp
+		assert.Equal(t, ctx, snmp.Context)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, context.Background(), session.Context)
+}
+
+func TestPoolCancelAbortsPendingRequest(t *testing.T) {
+	// An agent that never answers
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	pool := NewPool(config.SnmpConfig{
+		Ip: "127.0.0.1", Port: uint16(conn.LocalAddr().(*net.UDPAddr).Port), Community: "public", PoolSize: 1, Timeout: 10,
+	})
+	defer pool.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	err = pool.Do(ctx, func(snmp *gosnmp.GoSNMP) error {
+		_, err := snmp.Get([]string{SysUpTimeOID})
+		return err
+	})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestPoolRetries(t *testing.T) {
+	retries := func(value int) *int { return &value }
+
+	assert.Equal(t, defaultRetries, NewPool(config.SnmpConfig{}).retries)
+	assert.Equal(t, 0, NewPool(config.SnmpConfig{Retries: retries(0)}).retries)
+	assert.Equal(t, 3, NewPool(config.SnmpConfig{Retries: retries(3)}).retries)
+}
+
+func TestPoolHealthCheckUsesLimiter(t *testing.T) {
+	store, err := snmpsim.Load(strings.NewReader(".1.3.6.1.2.1.1.3.0 = Timeticks: (100) 0:00:01.00\n"))
+	assert.NoError(t, err)
+	server, err := snmpsim.Start("127.0.0.1:0", store, "public")
+	assert.NoError(t, err)
+	defer server.Close()
+
+	pool := NewPool(config.SnmpConfig{
+		Ip: "127.0.0.1", Port: uint16(server.Addr().(*net.UDPAddr).Port), Community: "public", PoolSize: 1,
+	})
+	defer pool.Close()
+
+	assert.NoError(t, pool.Do(context.Background(), func(*gosnmp.GoSNMP) error { return nil }))
+
+	// Age the idle session past the health check interval
+	s := <-pool.idle
+	s.lastUsed = time.Now().Add(-2 * pool.healthCheckInterval)
+	pool.idle <- s
+
+	// The sysUpTime probe is sent as a PDU of the limiter before the request runs
+	err = pool.Do(context.Background(), func(snmp *gosnmp.GoSNMP) error {
+		assert.Equal(t, int64(1), pool.Limiter().Stats().PDUs)
+		assert.Equal(t, 1, pool.Limiter().Stats().InFlight)
+		return nil
+	})
+	assert.NoError(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/achyar10/snmp-olt-zte/config"
@@ -11,48 +12,194 @@ import (
 	"github.com/gosnmp/gosnmp"
 )
 
-var (
-	snmpHost      string // SNMP host
-	snmpPort      uint16 // SNMP port
-	snmpCommunity string // SNMP community
-	//logSnmp       gosnmp.Logger // Logger for SNMP
-)
+// authProtocols maps the configured SNMPv3 authentication protocol to gosnmp
+var authProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	"MD5":    gosnmp.MD5,
+	"SHA":    gosnmp.SHA,
+	"SHA224": gosnmp.SHA224,
+	"SHA256": gosnmp.SHA256,
+	"SHA384": gosnmp.SHA384,
+	"SHA512": gosnmp.SHA512,
+}
+
+// privProtocols maps the configured SNMPv3 privacy protocol to gosnmp
+var privProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	"DES":     gosnmp.DES,
+	"AES":     gosnmp.AES,
+	"AES192":  gosnmp.AES192,
+	"AES256":  gosnmp.AES256,
+	"AES192C": gosnmp.AES192C,
+	"AES256C": gosnmp.AES256C,
+}
+
+// LoadSnmpConfig is a function to get the SNMP configuration of the default OLT. In the development and production
+// environments the environment variables that are set override the values of the config file.
+func LoadSnmpConfig(cfg *config.Config) config.SnmpConfig {
+	snmpCfg := cfg.SnmpCfg
+
+	// Check if the application is running in development or production environment
+	if os.Getenv("APP_ENV") != "development" && os.Getenv("APP_ENV") != "production" {
+		return snmpCfg
+	}
+
+	overrideString(&snmpCfg.Ip, "SNMP_HOST")
+	if value, ok := lookupEnv("SNMP_PORT"); ok {
+		snmpCfg.Port = utils.ConvertStringToUint16(value)
+	}
+	overrideString(&snmpCfg.Community, "SNMP_COMMUNITY")
+	overrideString(&snmpCfg.Version, "SNMP_VERSION")
+	overrideString(&snmpCfg.Username, "SNMP_USERNAME")
+	overrideString(&snmpCfg.AuthProtocol, "SNMP_AUTH_PROTOCOL")
+	overrideString(&snmpCfg.AuthPassphrase, "SNMP_AUTH_PASSPHRASE")
+	overrideString(&snmpCfg.PrivProtocol, "SNMP_PRIV_PROTOCOL")
+	overrideString(&snmpCfg.PrivPassphrase, "SNMP_PRIV_PASSPHRASE")
+	overrideString(&snmpCfg.ContextName, "SNMP_CONTEXT_NAME")
+
+	overrideInteger(&snmpCfg.PoolSize, "SNMP_POOL_SIZE")
+	overrideInteger(&snmpCfg.PoolTimeout, "SNMP_POOL_TIMEOUT")
+	overrideInteger(&snmpCfg.Timeout, "SNMP_TIMEOUT")
+	if value, ok := lookupEnv("SNMP_RETRIES"); ok {
+		retries := utils.ConvertStringToInteger(value)
+		snmpCfg.Retries = &retries
+	}
+	overrideInteger(&snmpCfg.HealthCheckInterval, "SNMP_HEALTH_CHECK_INTERVAL")
+	overrideInteger(&snmpCfg.MaxOids, "SNMP_MAX_OIDS")
+	overrideInteger(&snmpCfg.BreakerThreshold, "SNMP_BREAKER_THRESHOLD")
+	overrideInteger(&snmpCfg.BreakerCooldown, "SNMP_BREAKER_COOLDOWN")
+	overrideInteger(&snmpCfg.BreakerMaxCooldown, "SNMP_BREAKER_MAX_COOLDOWN")
+	overrideInteger(&snmpCfg.MaxInFlight, "SNMP_MAX_IN_FLIGHT")
+	overrideInteger(&snmpCfg.MaxQueue, "SNMP_MAX_QUEUE")
+	overrideInteger(&snmpCfg.MaxPDUsPerSecond, "SNMP_MAX_PDUS_PER_SECOND")
+
+	overrideString(&snmpCfg.RecordFile, "SNMP_RECORD_FILE")
+	overrideString(&snmpCfg.ReplayFile, "SNMP_REPLAY_FILE")
+
+	return snmpCfg
+}
+
+// lookupEnv returns the value of an environment variable, ok is false when it is not set or empty
+func lookupEnv(key string) (string, bool) {
+	value, ok := os.LookupEnv(key)
+	return value, ok && value != ""
+}
+
+// overrideString sets the value from an environment variable when it is set
+func overrideString(value *string, key string) {
+	if env, ok := lookupEnv(key); ok {
+		*value = env
+	}
+}
+
+// overrideInteger sets the value from an environment variable when it is set
+func overrideInteger(value *int, key string) {
+	if env, ok := lookupEnv(key); ok {
+		*value = utils.ConvertStringToInteger(env)
+	}
+}
+
+// NewSnmpTarget is a function to create an SNMP target instance, without connecting it
+func NewSnmpTarget(snmpCfg config.SnmpConfig) (*gosnmp.GoSNMP, error) {
+	// Check if SNMP configuration is valid
+	if snmpCfg.Ip == "" || snmpCfg.Port == 0 {
+		return nil, fmt.Errorf("konfigurasi SNMP tidak valid")
+	}
+
+	target := &gosnmp.GoSNMP{
+		Target:  snmpCfg.Ip,
+		Port:    snmpCfg.Port,
+		MaxOids: snmpCfg.MaxOids, // gosnmp uses its default of 60 when not set
+	}
+
+	switch strings.ToLower(snmpCfg.Version) {
+	case "", "2c", "v2c":
+		// Check if SNMP community is valid
+		if snmpCfg.Community == "" {
+			return nil, fmt.Errorf("konfigurasi SNMP community tidak valid")
+		}
+
+		target.Version = gosnmp.Version2c
+		target.Community = snmpCfg.Community
+	case "3", "v3":
+		securityParameters, msgFlags, err := newUsmSecurityParameters(snmpCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		target.Version = gosnmp.Version3
+		target.SecurityModel = gosnmp.UserSecurityModel
+		target.MsgFlags = msgFlags
+		target.SecurityParameters = securityParameters
+		target.ContextName = snmpCfg.ContextName
+	default:
+		return nil, fmt.Errorf("versi SNMP tidak didukung: %s", snmpCfg.Version)
+	}
+
+	return target, nil
+}
+
+// newUsmSecurityParameters is a function to build the SNMPv3 USM parameters and security level
+func newUsmSecurityParameters(snmpCfg config.SnmpConfig) (*gosnmp.UsmSecurityParameters, gosnmp.SnmpV3MsgFlags, error) {
+	// Check if SNMPv3 user name is valid
+	if snmpCfg.Username == "" {
+		return nil, 0, fmt.Errorf("konfigurasi SNMPv3 username tidak valid")
+	}
+
+	params := &gosnmp.UsmSecurityParameters{
+		UserName: snmpCfg.Username,
+	}
+
+	// Without authentication protocol the security level is noAuthNoPriv
+	if snmpCfg.AuthProtocol == "" {
+		if snmpCfg.PrivProtocol != "" {
+			return nil, 0, fmt.Errorf("SNMPv3 privacy protocol membutuhkan authentication protocol")
+		}
+		return params, gosnmp.NoAuthNoPriv, nil
+	}
+
+	authProtocol, ok := authProtocols[strings.ToUpper(snmpCfg.AuthProtocol)]
+	if !ok {
+		return nil, 0, fmt.Errorf("SNMPv3 authentication protocol tidak didukung: %s", snmpCfg.AuthProtocol)
+	}
+	params.AuthenticationProtocol = authProtocol
+	params.AuthenticationPassphrase = snmpCfg.AuthPassphrase
+
+	// Without privacy protocol the security level is authNoPriv
+	if snmpCfg.PrivProtocol == "" {
+		return params, gosnmp.AuthNoPriv, nil
+	}
+
+	privProtocol, ok := privProtocols[strings.ToUpper(snmpCfg.PrivProtocol)]
+	if !ok {
+		return nil, 0, fmt.Errorf("SNMPv3 privacy protocol tidak didukung: %s", snmpCfg.PrivProtocol)
+	}
+	params.PrivacyProtocol = privProtocol
+	params.PrivacyPassphrase = snmpCfg.PrivPassphrase
+
+	return params, gosnmp.AuthPriv, nil
+}
 
 // SetupSnmpConnection is a function to set up snmp connection
-func SetupSnmpConnection(config *config.Config) (*gosnmp.GoSNMP, error) {
+func SetupSnmpConnection(snmpCfg config.SnmpConfig) (*gosnmp.GoSNMP, error) {
 	var logSnmp gosnmp.Logger
 
-	// Check if the application is running in development or production environment
+	// Only log SNMP packets outside development and production environment
 	if os.Getenv("APP_ENV") == "development" || os.Getenv("APP_ENV") == "production" {
-		snmpHost = os.Getenv("SNMP_HOST")
-		snmpPort = utils.ConvertStringToUint16(os.Getenv("SNMP_PORT"))
-		snmpCommunity = os.Getenv("SNMP_COMMUNITY")
 		logSnmp = gosnmp.Logger{}
 	} else {
-		snmpHost = config.SnmpCfg.Ip
-		snmpPort = config.SnmpCfg.Port
-		snmpCommunity = config.SnmpCfg.Community
 		logSnmp = gosnmp.NewLogger(log.New(os.Stdout, "", 0))
 	}
 
-	// Check if SNMP configuration is valid
-	if snmpHost == "" || snmpPort == 0 || snmpCommunity == "" {
-		return nil, fmt.Errorf("konfigurasi SNMP tidak valid")
-	}
-
 	// Create a new SNMP target instance
-	target := &gosnmp.GoSNMP{
-		Target:    snmpHost,
-		Port:      snmpPort,
-		Community: snmpCommunity,
-		Version:   gosnmp.Version2c,
-		Timeout:   time.Duration(30) * time.Second,
-		Retries:   3,
-		Logger:    logSnmp,
+	target, err := NewSnmpTarget(snmpCfg)
+	if err != nil {
+		return nil, err
 	}
+	target.Timeout = time.Duration(30) * time.Second
+	target.Retries = 3
+	target.Logger = logSnmp
 
 	// Connect to the SNMP target
-	err := target.Connect()
+	err = target.Connect()
 	if err != nil {
 		return nil, fmt.Errorf("gagal terhubung ke SNMP: %w", err)
 	}
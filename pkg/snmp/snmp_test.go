@@ -0,0 +1,115 @@
+package snmp
+
+import (
+	"testing"
+
+	"github.com/achyar10/snmp-olt-zte/config"
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSnmpTargetVersion2c(t *testing.T) {
+	target, err := NewSnmpTarget(config.SnmpConfig{Ip: "127.0.0.1", Port: 161, Community: "public"})
+	assert.NoError(t, err)
+	assert.Equal(t, gosnmp.Version2c, target.Version)
+	assert.Equal(t, "public", target.Community)
+
+	_, err = NewSnmpTarget(config.SnmpConfig{Ip: "127.0.0.1", Port: 161})
+	assert.Error(t, err, "community is required for SNMPv2c")
+}
+
+func TestNewSnmpTargetVersion3(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cfg      config.SnmpConfig
+		msgFlags gosnmp.SnmpV3MsgFlags
+		wantErr  bool
+	}{
+		{
+			name:     "noAuthNoPriv",
+			cfg:      config.SnmpConfig{Username: "olt"},
+			msgFlags: gosnmp.NoAuthNoPriv,
+		},
+		{
+			name:     "authNoPriv",
+			cfg:      config.SnmpConfig{Username: "olt", AuthProtocol: "sha", AuthPassphrase: "authpass"},
+			msgFlags: gosnmp.AuthNoPriv,
+		},
+		{
+			name: "authPriv",
+			cfg: config.SnmpConfig{
+				Username: "olt", AuthProtocol: "SHA256", AuthPassphrase: "authpass",
+				PrivProtocol: "AES", PrivPassphrase: "privpass",
+			},
+			msgFlags: gosnmp.AuthPriv,
+		},
+		{
+			name:    "missing username",
+			cfg:     config.SnmpConfig{AuthProtocol: "SHA"},
+			wantErr: true,
+		},
+		{
+			name:    "privacy without authentication",
+			cfg:     config.SnmpConfig{Username: "olt", PrivProtocol: "AES"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown authentication protocol",
+			cfg:     config.SnmpConfig{Username: "olt", AuthProtocol: "SHA1024"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cfg.Ip, tc.cfg.Port, tc.cfg.Version, tc.cfg.ContextName = "127.0.0.1", 161, "3", "ctx"
+
+			target, err := NewSnmpTarget(tc.cfg)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, gosnmp.Version3, target.Version)
+			assert.Equal(t, gosnmp.UserSecurityModel, target.SecurityModel)
+			assert.Equal(t, tc.msgFlags, target.MsgFlags)
+			assert.Equal(t, "ctx", target.ContextName)
+		})
+	}
+}
+
+func TestNewSnmpTargetUnknownVersion(t *testing.T) {
+	_, err := NewSnmpTarget(config.SnmpConfig{Ip: "127.0.0.1", Port: 161, Community: "public", Version: "1"})
+	assert.Error(t, err)
+}
+
+func TestLoadSnmpConfigKeepsConfigFile(t *testing.T) {
+	cfg, err := config.LoadConfig("../../config/config-prod")
+	assert.NoError(t, err)
+
+	t.Setenv("APP_ENV", "production")
+	t.Setenv("SNMP_HOST", "10.0.0.1")
+	t.Setenv("SNMP_COMMUNITY", "private")
+	t.Setenv("SNMP_POOL_SIZE", "") // Empty variables do not override the config file
+	t.Setenv("SNMP_RETRIES", "0")
+
+	snmpCfg := LoadSnmpConfig(cfg)
+
+	// Variables that are set override the config file
+	assert.Equal(t, "10.0.0.1", snmpCfg.Ip)
+	assert.Equal(t, "private", snmpCfg.Community)
+	assert.Equal(t, 0, *snmpCfg.Retries)
+
+	// Pool, breaker and limiter settings of the config file survive
+	assert.Equal(t, cfg.SnmpCfg.Port, snmpCfg.Port)
+	assert.Equal(t, 4, snmpCfg.PoolSize)
+	assert.Equal(t, 10, snmpCfg.PoolTimeout)
+	assert.Equal(t, 60, snmpCfg.MaxOids)
+	assert.Equal(t, 5, snmpCfg.BreakerThreshold)
+	assert.Equal(t, 5, snmpCfg.BreakerCooldown)
+	assert.Equal(t, 300, snmpCfg.BreakerMaxCooldown)
+	assert.Equal(t, 4, snmpCfg.MaxInFlight)
+	assert.Equal(t, 100, snmpCfg.MaxQueue)
+	assert.Equal(t, 50, snmpCfg.MaxPDUsPerSecond)
+}
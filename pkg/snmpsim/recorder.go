@@ -0,0 +1,101 @@
+package snmpsim
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// Recorder writes varbinds as snmprec lines (oid|tag|value), the fixture format read by Load
+type Recorder struct {
+	mu       sync.Mutex
+	w        io.Writer
+	recorded map[string]string // Last written line by OID, a varbind is written again only when its value changed
+}
+
+// NewRecorder is a constructor function to create a recorder writing to w
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{
+		w:        w,
+		recorded: make(map[string]string),
+	}
+}
+
+// Record writes the given varbinds, exceptions like noSuchInstance are skipped since a missing OID replays the same way.
+// It is safe for concurrent use.
+func (r *Recorder) Record(pdus ...gosnmp.SnmpPDU) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, pdu := range pdus {
+		line, ok, err := FormatSnmprec(pdu)
+		if err != nil {
+			return err
+		}
+		if !ok || r.recorded[pdu.Name] == line {
+			continue
+		}
+
+		if _, err := io.WriteString(r.w, line+"\n"); err != nil {
+			return err
+		}
+		r.recorded[pdu.Name] = line
+	}
+	return nil
+}
+
+// FormatSnmprec formats a varbind as a snmprec line, ok is false for exceptions that are not data
+func FormatSnmprec(pdu gosnmp.SnmpPDU) (line string, ok bool, err error) {
+	oid := strings.TrimPrefix(pdu.Name, ".")
+
+	var tag, value string
+	switch pdu.Type {
+	case gosnmp.Integer:
+		tag = "2"
+		value = gosnmp.ToBigInt(pdu.Value).String()
+	case gosnmp.OctetString:
+		octets, isBytes := pdu.Value.([]byte)
+		if !isBytes {
+			return "", false, fmt.Errorf("snmpsim: %s OctetString value is %T", pdu.Name, pdu.Value)
+		}
+		tag, value = "4", string(octets)
+		if !isPrintable(octets) {
+			tag, value = "4x", hex.EncodeToString(octets)
+		}
+	case gosnmp.Null:
+		tag = "5"
+	case gosnmp.ObjectIdentifier:
+		tag = "6"
+		value = strings.TrimPrefix(fmt.Sprint(pdu.Value), ".")
+	case gosnmp.IPAddress:
+		tag = "64"
+		value = fmt.Sprint(pdu.Value)
+	case gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks:
+		tag = map[gosnmp.Asn1BER]string{gosnmp.Counter32: "65", gosnmp.Gauge32: "66", gosnmp.TimeTicks: "67"}[pdu.Type]
+		value = gosnmp.ToBigInt(pdu.Value).String()
+	case gosnmp.Counter64:
+		tag = "70"
+		value = gosnmp.ToBigInt(pdu.Value).String()
+	case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+		return "", false, nil
+	default:
+		return "", false, fmt.Errorf("snmpsim: %s has unsupported type %s", pdu.Name, pdu.Type)
+	}
+
+	return oid + "|" + tag + "|" + value, true, nil
+}
+
+// isPrintable checks if an octet string can be written as plain snmprec text
+func isPrintable(octets []byte) bool {
+	for _, r := range string(octets) {
+		if r == unicode.ReplacementChar || r == '|' || !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
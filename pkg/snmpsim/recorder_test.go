@@ -0,0 +1,60 @@
+package snmpsim
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatSnmprec(t *testing.T) {
+	testCases := []struct {
+		pdu  gosnmp.SnmpPDU
+		line string
+	}{
+		{gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.5.0", Type: gosnmp.OctetString, Value: []byte("olt")}, "1.3.6.1.2.1.1.5.0|4|olt"},
+		{gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.6.0", Type: gosnmp.OctetString, Value: []byte{0x07, 0xE8, 0x05}}, "1.3.6.1.2.1.1.6.0|4x|07e805"},
+		{gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.7.0", Type: gosnmp.OctetString, Value: []byte("a|b")}, "1.3.6.1.2.1.1.7.0|4x|617c62"},
+		{gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.8.10", Type: gosnmp.Integer, Value: -2}, "1.3.6.1.2.1.2.2.1.8.10|2|-2"},
+		{gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(263512345)}, "1.3.6.1.2.1.1.3.0|67|263512345"},
+		{gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.31.1.1.1.6.10", Type: gosnmp.Counter64, Value: uint64(12345678901)}, "1.3.6.1.2.1.31.1.1.1.6.10|70|12345678901"},
+		{gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.4.20.1.1.10.0.0.1", Type: gosnmp.IPAddress, Value: "10.0.0.1"}, "1.3.6.1.2.1.4.20.1.1.10.0.0.1|64|10.0.0.1"},
+		{gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.2.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.3902.1082"}, "1.3.6.1.2.1.1.2.0|6|1.3.6.1.4.1.3902.1082"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.line, func(t *testing.T) {
+			line, ok, err := FormatSnmprec(tc.pdu)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, tc.line, line)
+
+			// The line loads back to the same varbind
+			store, err := Load(strings.NewReader(line))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.pdu, store.Lookup(tc.pdu.Name))
+		})
+	}
+
+	_, ok, err := FormatSnmprec(gosnmp.SnmpPDU{Name: ".1.3", Type: gosnmp.NoSuchInstance})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRecorder(t *testing.T) {
+	var out bytes.Buffer
+	recorder := NewRecorder(&out)
+
+	name := gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.5.0", Type: gosnmp.OctetString, Value: []byte("olt")}
+	assert.NoError(t, recorder.Record(name, name))
+	assert.NoError(t, recorder.Record(gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.5.0", Type: gosnmp.OctetString, Value: []byte("olt-2")}))
+
+	// A repeated varbind is written once, a changed value is written again and wins on load
+	assert.Equal(t, "1.3.6.1.2.1.1.5.0|4|olt\n1.3.6.1.2.1.1.5.0|4|olt-2\n", out.String())
+	store, err := Load(&out)
+	assert.NoError(t, err)
+	record, _ := store.Get(".1.3.6.1.2.1.1.5.0")
+	assert.Equal(t, []byte("olt-2"), record.Value)
+}
@@ -0,0 +1,235 @@
+package snmpsim
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/rs/zerolog/log"
+)
+
+// maxResponseSize is the largest SNMP message sent in one UDP datagram
+const maxResponseSize = 65507
+
+// Server is an SNMP v1/v2c agent answering Get, GetNext and GetBulk requests from a recorded walk
+type Server struct {
+	store     *Store
+	community string         // Community accepted by the agent, any community when empty
+	decoder   *gosnmp.GoSNMP // Used to decode the requests
+
+	// MaxVarbinds is the max varbinds of a Get request, larger requests are answered with tooBig (0 is unlimited).
+	// It must be set before Serve.
+	MaxVarbinds int
+
+	mu   sync.Mutex
+	conn net.PacketConn
+}
+
+// NewServer is a constructor function to create a new simulated agent serving the given store
+func NewServer(store *Store, community string) *Server {
+	return &Server{
+		store:     store,
+		community: community,
+		decoder:   &gosnmp.GoSNMP{Version: gosnmp.Version2c},
+	}
+}
+
+// Listen binds the agent to a UDP address, e.g. 127.0.0.1:1161 or 127.0.0.1:0 for a random port
+func (s *Server) Listen(addr string) error {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+	return nil
+}
+
+// Addr returns the address the agent is bound to
+func (s *Server) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.LocalAddr()
+}
+
+// Serve answers requests until the agent is closed
+func (s *Server) Serve() error {
+	s.mu.Lock()
+	conn := s.conn
+	s.mu.Unlock()
+	if conn == nil {
+		return errors.New("snmpsim: Listen must be called before Serve")
+	}
+
+	buf := make([]byte, 65535)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		response, err := s.handle(buf[:n])
+		if err != nil {
+			log.Debug().Err(err).Str("from", addr.String()).Msg("snmpsim: dropped request")
+			continue
+		}
+		if _, err := conn.WriteTo(response, addr); err != nil {
+			log.Error().Err(err).Str("to", addr.String()).Msg("snmpsim: failed to send response")
+		}
+	}
+}
+
+// ListenAndServe binds the agent to a UDP address and answers requests until the agent is closed
+func (s *Server) ListenAndServe(addr string) error {
+	if err := s.Listen(addr); err != nil {
+		return err
+	}
+	return s.Serve()
+}
+
+// Start is a function to start a simulated agent in the background, e.g. on 127.0.0.1:0 in tests
+func Start(addr string, store *Store, community string) (*Server, error) {
+	server := NewServer(store, community)
+	if err := server.Listen(addr); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		if err := server.Serve(); err != nil {
+			log.Error().Err(err).Msg("snmpsim: agent stopped")
+		}
+	}()
+	return server, nil
+}
+
+// Close stops the agent
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// handle decodes a request and builds its response
+func (s *Server) handle(request []byte) ([]byte, error) {
+	packet, err := s.decoder.SnmpDecodePacket(request)
+	if err != nil {
+		return nil, err
+	}
+	if packet.Version == gosnmp.Version3 {
+		return nil, errors.New("SNMPv3 is not supported")
+	}
+	if s.community != "" && packet.Community != s.community {
+		return nil, errors.New("wrong community")
+	}
+
+	response := &gosnmp.SnmpPacket{
+		Version:   packet.Version,
+		Community: packet.Community,
+		PDUType:   gosnmp.GetResponse,
+		RequestID: packet.RequestID,
+	}
+
+	switch packet.PDUType {
+	case gosnmp.GetRequest:
+		// A response too big for the agent has an empty variable bindings list
+		if s.MaxVarbinds > 0 && len(packet.Variables) > s.MaxVarbinds {
+			response.Error = gosnmp.TooBig
+			break
+		}
+		response.Variables = s.get(packet.Variables)
+	case gosnmp.GetNextRequest:
+		response.Variables = s.getNext(packet.Variables)
+	case gosnmp.GetBulkRequest:
+		response.Variables = s.getBulk(packet.Variables, int(packet.NonRepeaters), int(packet.MaxRepetitions))
+	default:
+		return nil, errors.New("unsupported PDU type " + packet.PDUType.String())
+	}
+
+	// SNMPv1 has no exceptions in varbinds, missing OIDs are reported with noSuchName
+	if packet.Version == gosnmp.Version1 {
+		for i, variable := range response.Variables {
+			if isException(variable.Type) {
+				response.Error = gosnmp.NoSuchName
+				response.ErrorIndex = uint8(i + 1)
+				response.Variables = packet.Variables
+				break
+			}
+		}
+	}
+
+	return marshalResponse(response)
+}
+
+// get answers a Get request, reporting missing OIDs as noSuchObject or noSuchInstance
+func (s *Server) get(variables []gosnmp.SnmpPDU) []gosnmp.SnmpPDU {
+	result := make([]gosnmp.SnmpPDU, len(variables))
+	for i, variable := range variables {
+		result[i] = s.store.Lookup(variable.Name)
+	}
+	return result
+}
+
+// getNext answers a GetNext request, reporting the end of the recorded walk as endOfMibView
+func (s *Server) getNext(variables []gosnmp.SnmpPDU) []gosnmp.SnmpPDU {
+	result := make([]gosnmp.SnmpPDU, len(variables))
+	for i, variable := range variables {
+		result[i] = s.next(variable.Name)
+	}
+	return result
+}
+
+// getBulk answers a GetBulk request, the repeaters are repeated until every one of them reached the end of the walk
+func (s *Server) getBulk(variables []gosnmp.SnmpPDU, nonRepeaters, maxRepetitions int) []gosnmp.SnmpPDU {
+	nonRepeaters = min(max(nonRepeaters, 0), len(variables))
+	result := s.getNext(variables[:nonRepeaters])
+
+	names := make([]string, 0, len(variables)-nonRepeaters)
+	for _, variable := range variables[nonRepeaters:] {
+		names = append(names, variable.Name)
+	}
+
+	for repetition := 0; repetition < maxRepetitions && len(names) > 0; repetition++ {
+		ended := true
+		for i, name := range names {
+			pdu := s.next(name)
+			result = append(result, pdu)
+			names[i] = pdu.Name
+			ended = ended && pdu.Type == gosnmp.EndOfMibView
+		}
+		if ended {
+			break
+		}
+	}
+	return result
+}
+
+// next returns the varbind after the given OID
+func (s *Server) next(oid string) gosnmp.SnmpPDU {
+	return s.store.LookupNext(oid)
+}
+
+// marshalResponse encodes a response, dropping trailing varbinds of a GetBulk response that does not fit in a datagram
+func marshalResponse(response *gosnmp.SnmpPacket) ([]byte, error) {
+	for {
+		out, err := response.MarshalMsg()
+		if err != nil || len(out) <= maxResponseSize || len(response.Variables) <= 1 {
+			return out, err
+		}
+		response.Variables = response.Variables[:len(response.Variables)/2]
+	}
+}
+
+// isException checks if a varbind type is an SNMPv2 exception
+func isException(berType gosnmp.Asn1BER) bool {
+	return berType == gosnmp.NoSuchObject || berType == gosnmp.NoSuchInstance || berType == gosnmp.EndOfMibView
+}
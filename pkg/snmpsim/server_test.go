@@ -0,0 +1,129 @@
+package snmpsim
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+)
+
+const testWalk = `.1.3.6.1.2.1.1.3.0 = Timeticks: (263512345) 30 days, 11:58:43.45
+.1.3.6.1.2.1.1.5.0 = STRING: "OLT-C320-LAB"
+.1.3.6.1.2.1.2.2.1.2.1 = STRING: "gpon_1/1/1"
+.1.3.6.1.2.1.2.2.1.2.2 = STRING: "gpon_1/1/2"
+.1.3.6.1.2.1.2.2.1.10.1 = Counter32: 100
+.1.3.6.1.2.1.2.2.1.10.2 = Counter32: 200
+`
+
+// newTestClient starts an agent serving the walk and connects a client to it
+func newTestClient(t *testing.T, walk string, version gosnmp.SnmpVersion, community string) *gosnmp.GoSNMP {
+	store, err := Load(strings.NewReader(walk))
+	assert.NoError(t, err)
+
+	server, err := Start("127.0.0.1:0", store, "public")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = server.Close() })
+
+	client := &gosnmp.GoSNMP{
+		Target:    "127.0.0.1",
+		Port:      uint16(server.Addr().(*net.UDPAddr).Port),
+		Community: community,
+		Version:   version,
+		Timeout:   500 * time.Millisecond,
+		Retries:   0,
+	}
+	assert.NoError(t, client.Connect())
+	t.Cleanup(func() { _ = client.Conn.Close() })
+	return client
+}
+
+func TestServerGet(t *testing.T) {
+	client := newTestClient(t, testWalk, gosnmp.Version2c, "public")
+
+	result, err := client.Get([]string{".1.3.6.1.2.1.1.5.0", ".1.3.6.1.2.1.2.2.1.2.9", ".1.3.6.1.2.1.9.9.0"})
+	assert.NoError(t, err)
+	assert.Len(t, result.Variables, 3)
+	assert.Equal(t, []byte("OLT-C320-LAB"), result.Variables[0].Value)
+	assert.Equal(t, gosnmp.NoSuchInstance, result.Variables[1].Type)
+	assert.Equal(t, gosnmp.NoSuchObject, result.Variables[2].Type)
+}
+
+func TestServerWalk(t *testing.T) {
+	client := newTestClient(t, testWalk, gosnmp.Version2c, "public")
+
+	for _, walk := range []func(string, gosnmp.WalkFunc) error{client.Walk, client.BulkWalk} {
+		var names []string
+		err := walk(".1.3.6.1.2.1.2.2.1", func(pdu gosnmp.SnmpPDU) error {
+			names = append(names, pdu.Name)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			".1.3.6.1.2.1.2.2.1.2.1", ".1.3.6.1.2.1.2.2.1.2.2", ".1.3.6.1.2.1.2.2.1.10.1", ".1.3.6.1.2.1.2.2.1.10.2",
+		}, names)
+	}
+}
+
+func TestServerGetBulkEndOfMibView(t *testing.T) {
+	client := newTestClient(t, testWalk, gosnmp.Version2c, "public")
+
+	result, err := client.GetBulk([]string{".1.3.6.1.2.1.2.2.1.10.1"}, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, result.Variables, 2)
+	assert.Equal(t, uint(200), result.Variables[0].Value)
+	assert.Equal(t, gosnmp.EndOfMibView, result.Variables[1].Type)
+}
+
+func TestServerVersion1(t *testing.T) {
+	client := newTestClient(t, testWalk, gosnmp.Version1, "public")
+
+	result, err := client.Get([]string{".1.3.6.1.2.1.1.5.0"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("OLT-C320-LAB"), result.Variables[0].Value)
+
+	result, err = client.Get([]string{".1.3.6.1.2.1.1.5.0", ".1.3.6.1.2.1.9.9.0"})
+	assert.NoError(t, err)
+	assert.Equal(t, gosnmp.NoSuchName, result.Error)
+	assert.Equal(t, uint8(2), result.ErrorIndex)
+}
+
+func TestServerWrongCommunity(t *testing.T) {
+	client := newTestClient(t, testWalk, gosnmp.Version2c, "private")
+
+	_, err := client.Get([]string{".1.3.6.1.2.1.1.5.0"})
+	assert.Error(t, err)
+}
+
+func TestServerMaxVarbinds(t *testing.T) {
+	store, err := Load(strings.NewReader(testWalk))
+	assert.NoError(t, err)
+
+	server := NewServer(store, "public")
+	server.MaxVarbinds = 1
+	assert.NoError(t, server.Listen("127.0.0.1:0"))
+	go func() { _ = server.Serve() }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	client := &gosnmp.GoSNMP{
+		Target:    "127.0.0.1",
+		Port:      uint16(server.Addr().(*net.UDPAddr).Port),
+		Community: "public",
+		Version:   gosnmp.Version2c,
+		Timeout:   500 * time.Millisecond,
+	}
+	assert.NoError(t, client.Connect())
+	t.Cleanup(func() { _ = client.Conn.Close() })
+
+	result, err := client.Get([]string{".1.3.6.1.2.1.1.3.0", ".1.3.6.1.2.1.1.5.0"})
+	assert.NoError(t, err)
+	assert.Equal(t, gosnmp.TooBig, result.Error)
+	assert.Empty(t, result.Variables)
+
+	result, err = client.Get([]string{".1.3.6.1.2.1.1.5.0"})
+	assert.NoError(t, err)
+	assert.Equal(t, gosnmp.NoError, result.Error)
+	assert.Equal(t, []byte("OLT-C320-LAB"), result.Variables[0].Value)
+}
@@ -0,0 +1,386 @@
+package snmpsim
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// Record is one OID of a recorded agent with its value
+type Record struct {
+	OID   string // Numeric OID with a leading dot
+	Type  gosnmp.Asn1BER
+	Value interface{} // int, []byte, uint32, uint64 or string, as gosnmp marshals the type
+	sub   []uint32    // Parsed OID, used for ordering
+}
+
+// Store is an ordered, read-only set of recorded OIDs
+type Store struct {
+	records []Record       // Records in OID order
+	index   map[string]int // Record position by OID
+}
+
+// walkLine matches a line of "snmpwalk -On" output, e.g. .1.3.6.1.2.1.1.5.0 = STRING: "olt"
+var walkLine = regexp.MustCompile(`^(\.?\d+(?:\.\d+)*) = (.*)$`)
+
+// LoadFile is a function to load a recorded walk from a file, see Load for the supported formats
+func LoadFile(path string) (*Store, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Load(file)
+}
+
+// Load is a function to load a recorded walk, either "snmpwalk -On" output or snmprec lines (oid|tag|value).
+// Lines starting with # and blank lines are ignored.
+func Load(r io.Reader) (*Store, error) {
+	var records []Record
+	var pending *walkRecord // "snmpwalk -On" record whose value may continue on the next lines
+
+	// flush parses the pending "snmpwalk -On" record
+	flush := func() error {
+		if pending == nil {
+			return nil
+		}
+		record, ok, err := pending.parse()
+		pending = nil
+		if err != nil {
+			return err
+		}
+		if ok {
+			records = append(records, record)
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if match := walkLine.FindStringSubmatch(line); match != nil {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			pending = &walkRecord{line: lineNumber, oid: match[1], value: match[2]}
+			continue
+		}
+
+		if fields := strings.SplitN(line, "|", 3); len(fields) == 3 {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			record, err := parseSnmprec(fields)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			records = append(records, record)
+			continue
+		}
+
+		// snmpwalk wraps long values, e.g. Hex-STRING, over several lines
+		if pending == nil {
+			return nil, fmt.Errorf("line %d: unrecognized line %q", lineNumber, line)
+		}
+		pending.value += "\n" + line
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return NewStore(records)
+}
+
+// NewStore is a constructor function to create a store from records, the last record of a duplicated OID wins
+func NewStore(records []Record) (*Store, error) {
+	store := &Store{index: make(map[string]int, len(records))}
+
+	byOID := make(map[string]Record, len(records))
+	for _, record := range records {
+		sub, err := parseOID(record.OID)
+		if err != nil {
+			return nil, err
+		}
+		record.OID = "." + strings.TrimPrefix(record.OID, ".")
+		record.sub = sub
+		byOID[record.OID] = record
+	}
+
+	for _, record := range byOID {
+		store.records = append(store.records, record)
+	}
+	sort.Slice(store.records, func(i, j int) bool {
+		return compareOID(store.records[i].sub, store.records[j].sub) < 0
+	})
+	for i, record := range store.records {
+		store.index[record.OID] = i
+	}
+
+	return store, nil
+}
+
+// Len returns the number of recorded OIDs
+func (s *Store) Len() int {
+	return len(s.records)
+}
+
+// Get returns the record of exactly the given OID
+func (s *Store) Get(oid string) (Record, bool) {
+	i, ok := s.index["."+strings.TrimPrefix(oid, ".")]
+	if !ok {
+		return Record{}, false
+	}
+	return s.records[i], true
+}
+
+// Next returns the first record after the given OID, in lexicographic OID order
+func (s *Store) Next(oid string) (Record, bool) {
+	sub, err := parseOID(oid)
+	if err != nil {
+		return Record{}, false
+	}
+
+	i := sort.Search(len(s.records), func(i int) bool {
+		return compareOID(s.records[i].sub, sub) > 0
+	})
+	if i == len(s.records) {
+		return Record{}, false
+	}
+	return s.records[i], true
+}
+
+// HasPrefix checks if any record is at or below the given OID, to tell noSuchObject from noSuchInstance
+func (s *Store) HasPrefix(oid string) bool {
+	prefix := "." + strings.Trim(oid, ".")
+	if _, ok := s.Get(prefix); ok {
+		return true
+	}
+	next, ok := s.Next(prefix)
+	return ok && strings.HasPrefix(next.OID, prefix+".")
+}
+
+// Lookup returns the varbind of exactly the given OID, a missing OID is answered with noSuchObject or noSuchInstance
+func (s *Store) Lookup(oid string) gosnmp.SnmpPDU {
+	if record, ok := s.Get(oid); ok {
+		return record.PDU()
+	}
+
+	pdu := gosnmp.SnmpPDU{Name: oid, Type: gosnmp.NoSuchObject}
+	if s.HasPrefix(parentOID(oid)) {
+		pdu.Type = gosnmp.NoSuchInstance
+	}
+	return pdu
+}
+
+// LookupNext returns the varbind after the given OID, the end of the recorded walk is answered with endOfMibView
+func (s *Store) LookupNext(oid string) gosnmp.SnmpPDU {
+	record, ok := s.Next(oid)
+	if !ok {
+		return gosnmp.SnmpPDU{Name: oid, Type: gosnmp.EndOfMibView}
+	}
+	return record.PDU()
+}
+
+// PDU converts a record to a varbind
+func (r Record) PDU() gosnmp.SnmpPDU {
+	return gosnmp.SnmpPDU{Name: r.OID, Type: r.Type, Value: r.Value}
+}
+
+// walkRecord is a record of "snmpwalk -On" output before its value is parsed
+type walkRecord struct {
+	line  int
+	oid   string
+	value string
+}
+
+// parse parses the value of a "snmpwalk -On" record, ok is false for values that are not data, e.g. No Such Object
+func (w *walkRecord) parse() (Record, bool, error) {
+	record := Record{OID: w.oid}
+
+	// An empty string is printed without type
+	if w.value == `""` {
+		record.Type, record.Value = gosnmp.OctetString, []byte{}
+		return record, true, nil
+	}
+
+	kind, value, found := strings.Cut(w.value, ": ")
+	if !found {
+		kind, value = strings.TrimSuffix(w.value, ":"), ""
+	}
+
+	var err error
+	switch kind {
+	case "INTEGER":
+		record.Type = gosnmp.Integer
+		record.Value, err = parseInteger(value)
+	case "STRING":
+		record.Type = gosnmp.OctetString
+		record.Value, err = parseQuotedString(value)
+	case "Hex-STRING":
+		record.Type = gosnmp.OctetString
+		record.Value, err = hex.DecodeString(strings.Join(strings.Fields(value), ""))
+	case "Counter32", "Gauge32":
+		record.Type = map[string]gosnmp.Asn1BER{"Counter32": gosnmp.Counter32, "Gauge32": gosnmp.Gauge32}[kind]
+		record.Value, err = parseUint32(value)
+	case "Timeticks":
+		// Timeticks: (12345) 0:02:03.45
+		record.Type = gosnmp.TimeTicks
+		ticks, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimSpace(value), "("), ")")
+		record.Value, err = parseUint32(ticks)
+	case "Counter64":
+		record.Type = gosnmp.Counter64
+		record.Value, err = strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+	case "IpAddress":
+		record.Type, record.Value = gosnmp.IPAddress, strings.TrimSpace(value)
+	case "OID":
+		record.Type, record.Value = gosnmp.ObjectIdentifier, strings.TrimSpace(value)
+	default:
+		// No Such Object, No Such Instance, No more variables left in this MIB View, Opaque...
+		return Record{}, false, nil
+	}
+	if err != nil {
+		return Record{}, false, fmt.Errorf("line %d: invalid %s value %q: %w", w.line, kind, value, err)
+	}
+
+	return record, true, nil
+}
+
+// parseSnmprec parses the fields of a snmprec line: oid|tag|value, a tag ending in x has a hex encoded value
+func parseSnmprec(fields []string) (Record, error) {
+	record := Record{OID: fields[0]}
+	tag, value := fields[1], fields[2]
+
+	hexValue := strings.HasSuffix(tag, "x")
+	tag = strings.TrimSuffix(tag, "x")
+
+	var err error
+	switch tag {
+	case "2":
+		record.Type = gosnmp.Integer
+		record.Value, err = strconv.Atoi(value)
+	case "4":
+		record.Type = gosnmp.OctetString
+		if hexValue {
+			record.Value, err = hex.DecodeString(value)
+		} else {
+			record.Value = []byte(value)
+		}
+	case "5":
+		record.Type, record.Value = gosnmp.Null, nil
+	case "6":
+		record.Type, record.Value = gosnmp.ObjectIdentifier, "."+strings.TrimPrefix(value, ".")
+	case "64":
+		record.Type, record.Value = gosnmp.IPAddress, value
+		if hexValue {
+			ip, hexErr := hex.DecodeString(value)
+			if hexErr != nil || len(ip) != 4 {
+				return Record{}, fmt.Errorf("invalid IpAddress value %q", value)
+			}
+			record.Value = fmt.Sprintf("%d.%d.%d.%d", ip[0], ip[1], ip[2], ip[3])
+		}
+	case "65", "66", "67":
+		record.Type = map[string]gosnmp.Asn1BER{"65": gosnmp.Counter32, "66": gosnmp.Gauge32, "67": gosnmp.TimeTicks}[tag]
+		record.Value, err = parseUint32(value)
+	case "70":
+		record.Type = gosnmp.Counter64
+		record.Value, err = strconv.ParseUint(value, 10, 64)
+	default:
+		return Record{}, fmt.Errorf("unsupported snmprec tag %q", fields[1])
+	}
+	if err != nil {
+		return Record{}, fmt.Errorf("invalid value %q for tag %s: %w", value, fields[1], err)
+	}
+
+	return record, nil
+}
+
+// parseInteger parses an INTEGER value, enumerations are printed as name(value)
+func parseInteger(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if open := strings.LastIndex(value, "("); open >= 0 && strings.HasSuffix(value, ")") {
+		value = value[open+1 : len(value)-1]
+	}
+	return strconv.Atoi(value)
+}
+
+// parseQuotedString parses a STRING value, quoted by snmpwalk unless it was printed without quotes
+func parseQuotedString(value string) ([]byte, error) {
+	if !strings.HasPrefix(value, `"`) {
+		return []byte(value), nil
+	}
+
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		// snmpwalk does not escape all characters the way Go does, strip the quotes only
+		return []byte(strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)), nil
+	}
+	return []byte(unquoted), nil
+}
+
+// parseUint32 parses an unsigned 32 bit value
+func parseUint32(value string) (uint32, error) {
+	parsed, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+	return uint32(parsed), err
+}
+
+// parseOID parses a numeric OID, with or without leading dot
+func parseOID(oid string) ([]uint32, error) {
+	oid = strings.TrimPrefix(oid, ".")
+	if oid == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(oid, ".")
+	sub := make([]uint32, len(parts))
+	for i, part := range parts {
+		value, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OID %q", oid)
+		}
+		sub[i] = uint32(value)
+	}
+	return sub, nil
+}
+
+// compareOID compares two parsed OIDs in lexicographic order
+func compareOID(a, b []uint32) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(a) - len(b)
+}
+
+// parentOID returns the OID without its last sub-identifier
+func parentOID(oid string) string {
+	for i := len(oid) - 1; i >= 0; i-- {
+		if oid[i] == '.' {
+			return oid[:i]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+package snmpsim
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadWalk(t *testing.T) {
+	walk := `# snmpwalk -On
+.1.3.6.1.2.1.1.5.0 = STRING: "olt \"lab\""
+.1.3.6.1.2.1.1.3.0 = Timeticks: (263512345) 30 days, 11:58:43.45
+.1.3.6.1.2.1.2.2.1.8.10 = INTEGER: up(1)
+.1.3.6.1.2.1.2.2.1.10.10 = Counter32: 1234
+.1.3.6.1.2.1.31.1.1.1.6.10 = Counter64: 12345678901
+.1.3.6.1.2.1.2.2.1.5.10 = Gauge32: 1000000000
+.1.3.6.1.2.1.4.20.1.1.10.0.0.1 = IpAddress: 10.0.0.1
+.1.3.6.1.2.1.1.2.0 = OID: .1.3.6.1.4.1.3902.1082
+.1.3.6.1.2.1.1.4.0 = ""
+.1.3.6.1.4.1.3902.1082.500.10.2.3.8.1.5.285278465.1 = Hex-STRING: 07 E8 05 0E 08 1E 
+0F 00 
+.1.3.6.1.2.1.1.9.0 = No Such Object available on this agent at this OID
+`
+	store, err := Load(strings.NewReader(walk))
+	assert.NoError(t, err)
+	assert.Equal(t, 10, store.Len())
+
+	testCases := []struct {
+		oid     string
+		berType gosnmp.Asn1BER
+		value   interface{}
+	}{
+		{".1.3.6.1.2.1.1.5.0", gosnmp.OctetString, []byte(`olt "lab"`)},
+		{".1.3.6.1.2.1.1.3.0", gosnmp.TimeTicks, uint32(263512345)},
+		{".1.3.6.1.2.1.2.2.1.8.10", gosnmp.Integer, 1},
+		{".1.3.6.1.2.1.2.2.1.10.10", gosnmp.Counter32, uint32(1234)},
+		{".1.3.6.1.2.1.31.1.1.1.6.10", gosnmp.Counter64, uint64(12345678901)},
+		{".1.3.6.1.2.1.2.2.1.5.10", gosnmp.Gauge32, uint32(1000000000)},
+		{".1.3.6.1.2.1.4.20.1.1.10.0.0.1", gosnmp.IPAddress, "10.0.0.1"},
+		{".1.3.6.1.2.1.1.2.0", gosnmp.ObjectIdentifier, ".1.3.6.1.4.1.3902.1082"},
+		{".1.3.6.1.2.1.1.4.0", gosnmp.OctetString, []byte{}},
+		{".1.3.6.1.4.1.3902.1082.500.10.2.3.8.1.5.285278465.1", gosnmp.OctetString, []byte{0x07, 0xE8, 0x05, 0x0E, 0x08, 0x1E, 0x0F, 0x00}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.oid, func(t *testing.T) {
+			record, ok := store.Get(tc.oid)
+			assert.True(t, ok)
+			assert.Equal(t, tc.berType, record.Type)
+			assert.Equal(t, tc.value, record.Value)
+		})
+	}
+}
+
+func TestLoadSnmprec(t *testing.T) {
+	snmprec := `1.3.6.1.2.1.1.5.0|4|olt
+1.3.6.1.2.1.1.6.0|4x|6c6162
+1.3.6.1.2.1.2.2.1.8.10|2|1
+1.3.6.1.2.1.1.3.0|67|263512345
+1.3.6.1.2.1.31.1.1.1.6.10|70|12345678901
+1.3.6.1.2.1.4.20.1.1.10.0.0.1|64x|0a000001
+`
+	store, err := Load(strings.NewReader(snmprec))
+	assert.NoError(t, err)
+	assert.Equal(t, 6, store.Len())
+
+	record, _ := store.Get(".1.3.6.1.2.1.1.6.0")
+	assert.Equal(t, []byte("lab"), record.Value)
+	record, _ = store.Get("1.3.6.1.2.1.4.20.1.1.10.0.0.1")
+	assert.Equal(t, "10.0.0.1", record.Value)
+
+	_, err = Load(strings.NewReader("1.3.6.1.2.1.1.5.0|99|olt\n"))
+	assert.Error(t, err)
+}
+
+func TestStoreNext(t *testing.T) {
+	store, err := Load(strings.NewReader(`1.3.6.1.2.1.1.9|2|9
+1.3.6.1.2.1.1.10|2|10
+1.3.6.1.2.1.2.1|2|1
+`))
+	assert.NoError(t, err)
+
+	// OIDs are ordered by sub-identifier value, not as text
+	next, ok := store.Next(".1.3.6.1.2.1.1.9")
+	assert.True(t, ok)
+	assert.Equal(t, ".1.3.6.1.2.1.1.10", next.OID)
+
+	next, ok = store.Next(".1.3.6.1.2.1.1")
+	assert.True(t, ok)
+	assert.Equal(t, ".1.3.6.1.2.1.1.9", next.OID)
+
+	_, ok = store.Next(".1.3.6.1.2.1.2.1")
+	assert.False(t, ok)
+
+	assert.True(t, store.HasPrefix(".1.3.6.1.2.1.1"))
+	assert.False(t, store.HasPrefix(".1.3.6.1.2.1.3"))
+}
+
+func TestLoadC320WalkFixture(t *testing.T) {
+	store, err := LoadFile("testdata/zte-c320.walk")
+	assert.NoError(t, err)
+
+	// System group, 7 attributes for every PON and 20 attributes for every ONU of 2 boards with 16 PONs each, then
+	// the management ifName, 8 IF-MIB traffic attributes for every PON and the 2 uplinks and 6 inventory attributes
+	// of 2 ONUs and 3 attributes for every ETH UNI of an ONU with 4 ports, then 6 attributes for every card of 4
+	// slots, 2 attributes for every fan of 3 and temperature sensor of 2 and the status of 2 power supplies
+	assert.Equal(t, 4+7*32+20*88+1+8*34+6*2+3*4+6*4+2*3+2*2+2, store.Len())
+}